@@ -0,0 +1,50 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/dave/ebiten/internal/ui"
+)
+
+// ClipboardText returns the text in the system clipboard.
+//
+// ClipboardText returns an empty string when the clipboard is empty or
+// its content is not text.
+//
+// On browsers, ClipboardText uses the asynchronous Clipboard API and waits for the result for a short time.
+// If the browser doesn't permit reading the clipboard or the result is not available in time,
+// e.g., while the browser asks the player for the permission,
+// ClipboardText returns the text of the last paste event on the page or the last text set by SetClipboardText.
+//
+// In headless mode, i.e., on desktops while the game is not running and on Node.js,
+// and on mobiles, the system clipboard is not used. Instead, an in-memory clipboard
+// shared in the process is used: ClipboardText returns the last text set by SetClipboardText.
+//
+// This function is concurrent-safe.
+func ClipboardText() string {
+	return ui.ClipboardText()
+}
+
+// SetClipboardText sets the text to the system clipboard.
+//
+// On browsers, writing the clipboard is done asynchronously and might be rejected
+// e.g., when SetClipboardText is not called in response to a user's input.
+//
+// See ClipboardText for the behavior in headless mode and on mobiles.
+//
+// This function is concurrent-safe.
+func SetClipboardText(text string) {
+	ui.SetClipboardText(text)
+}
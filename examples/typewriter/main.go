@@ -15,61 +15,54 @@
 package main
 
 import (
+	"image/color"
 	"log"
 	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/image/font/basicfont"
 
 	"github.com/dave/ebiten"
 	"github.com/dave/ebiten/ebitenutil"
+	"github.com/dave/ebiten/text/textedit"
 )
 
 var (
-	text          = "Type on the keyboard:\n"
-	counter       = 0
-	bsPrevPressed = false
+	// field is the editable text. It handles the caret, the selection, key repeats and the clipboard.
+	field = &textedit.Field{
+		Face:      basicfont.Face7x13,
+		Multiline: true,
+		Width:     304,
+	}
 )
 
 func update(screen *ebiten.Image) error {
-	// Add a string from InputChars, that returns string input by users.
-	// Note that InputChars result changes every frame, so you need to call this
-	// every frame.
-	text += string(ebiten.InputChars())
+	// Update the field every frame to handle the characters input by users and the keys.
+	field.Update()
 
-	// Adjust the string to be at most 10 lines.
-	ss := strings.Split(text, "\n")
-	if len(ss) > 10 {
-		text = strings.Join(ss[len(ss)-10:], "\n")
+	// Adjust the text to be at most 10 lines.
+	if ss := strings.Split(field.Text(), "\n"); len(ss) > 10 {
+		// Keep the caret at the same place in the remaining lines.
+		removed := utf8.RuneCountInString(strings.Join(ss[:len(ss)-10], "\n")) + 1
+		caret := field.Caret() - removed
+		field.SetText(strings.Join(ss[len(ss)-10:], "\n"))
+		field.SetCaret(caret)
 	}
 
-	// If the enter key is pressed, add a line break.
-	if ebiten.IsKeyPressed(ebiten.KeyEnter) && !strings.HasSuffix(text, "\n") {
-		text += "\n"
-	}
-
-	// If the backspace key is pressed, remove one character.
-	bsPressed := ebiten.IsKeyPressed(ebiten.KeyBackspace)
-	if !bsPrevPressed && bsPressed {
-		if len(text) >= 1 {
-			text = text[:len(text)-1]
-		}
-	}
-	bsPrevPressed = bsPressed
-
-	counter++
-
 	if ebiten.IsRunningSlowly() {
 		return nil
 	}
 
-	// Blink the cursor.
-	t := text
-	if counter%60 < 30 {
-		t += "_"
-	}
-	ebitenutil.DebugPrint(screen, t)
+	ebitenutil.DebugPrint(screen, "Type on the keyboard:")
+	field.Draw(screen, 8, 40, color.White)
 	return nil
 }
 
 func main() {
+	// The field always has focus in this example, so the IME is always enabled.
+	// This is needed to input texts with the IME on browsers.
+	ebiten.SetIMEEnabled(true)
+
 	if err := ebiten.Run(update, 320, 240, 2.0, "Typewriter (Ebiten Demo)"); err != nil {
 		log.Fatal(err)
 	}
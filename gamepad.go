@@ -15,6 +15,7 @@
 package ebiten
 
 import (
+	"github.com/dave/ebiten/internal/gamepaddb"
 	"github.com/dave/ebiten/internal/ui"
 )
 
@@ -57,3 +58,46 @@ const (
 	GamepadButton31  GamepadButton = GamepadButton(ui.GamepadButton31)
 	GamepadButtonMax GamepadButton = GamepadButton31
 )
+
+// A StandardGamepadButton represents a gamepad button in the standard layout.
+//
+// The standard layout is the same as the standard gamepad of the W3C Gamepad API:
+// A, B, X and Y are the bottom, right, left and top face buttons respectively
+// in the same positions as Xbox controllers.
+type StandardGamepadButton int
+
+// StandardGamepadButtons
+const (
+	StandardGamepadButtonA            StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonA)
+	StandardGamepadButtonB            StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonB)
+	StandardGamepadButtonX            StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonX)
+	StandardGamepadButtonY            StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonY)
+	StandardGamepadButtonLeftBumper   StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonLeftBumper)
+	StandardGamepadButtonRightBumper  StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonRightBumper)
+	StandardGamepadButtonLeftTrigger  StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonLeftTrigger)
+	StandardGamepadButtonRightTrigger StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonRightTrigger)
+	StandardGamepadButtonBack         StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonBack)
+	StandardGamepadButtonStart        StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonStart)
+	StandardGamepadButtonLeftStick    StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonLeftStick)
+	StandardGamepadButtonRightStick   StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonRightStick)
+	StandardGamepadButtonDpadUp       StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonDpadUp)
+	StandardGamepadButtonDpadDown     StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonDpadDown)
+	StandardGamepadButtonDpadLeft     StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonDpadLeft)
+	StandardGamepadButtonDpadRight    StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonDpadRight)
+	StandardGamepadButtonGuide        StandardGamepadButton = StandardGamepadButton(gamepaddb.StandardButtonGuide)
+	StandardGamepadButtonMax          StandardGamepadButton = StandardGamepadButtonGuide
+)
+
+// A StandardGamepadAxis represents a gamepad axis in the standard layout.
+//
+// The horizontal axes' positive direction is right, and the vertical axes' positive direction is down.
+type StandardGamepadAxis int
+
+// StandardGamepadAxes
+const (
+	StandardGamepadAxisLeftStickHorizontal  StandardGamepadAxis = StandardGamepadAxis(gamepaddb.StandardAxisLeftStickX)
+	StandardGamepadAxisLeftStickVertical    StandardGamepadAxis = StandardGamepadAxis(gamepaddb.StandardAxisLeftStickY)
+	StandardGamepadAxisRightStickHorizontal StandardGamepadAxis = StandardGamepadAxis(gamepaddb.StandardAxisRightStickX)
+	StandardGamepadAxisRightStickVertical   StandardGamepadAxis = StandardGamepadAxis(gamepaddb.StandardAxisRightStickY)
+	StandardGamepadAxisMax                  StandardGamepadAxis = StandardGamepadAxisRightStickVertical
+)
@@ -26,6 +26,7 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/dave/ebiten/internal"
@@ -34,6 +35,39 @@ import (
 var (
 	nameToCodes       map[string][]string
 	keyCodeToNameEdge map[int]string
+
+	// modNameToSideNames represents the modifier keys without the left/right distinction.
+	modNameToSideNames = map[string][]string{
+		"Alt":     {"LeftAlt", "RightAlt"},
+		"Control": {"LeftControl", "RightControl"},
+		"Shift":   {"LeftShift", "RightShift"},
+	}
+
+	// appendedNames represents the keys added after the first key set in the order of their values.
+	// These keys are placed after the other keys so that the values of the existing keys don't change:
+	// the values might be saved e.g. in configuration files.
+	// Add a new key to the end of this list.
+	appendedNames = []string{
+		"F13", "F14", "F15", "F16", "F17", "F18", "F19", "F20", "F21", "F22", "F23", "F24",
+		"KP0", "KP1", "KP2", "KP3", "KP4", "KP5", "KP6", "KP7", "KP8", "KP9",
+		"KPAdd", "KPDecimal", "KPDivide", "KPEnter", "KPEqual", "KPMultiply", "KPSubtract",
+		"LeftAlt", "LeftControl", "LeftShift", "LeftSuper",
+		"MediaPlayPause", "MediaStop", "MediaTrackNext", "MediaTrackPrevious",
+		"Menu", "NumLock", "Pause", "PrintScreen",
+		"RightAlt", "RightControl", "RightShift", "RightSuper",
+		"ScrollLock", "VolumeDown", "VolumeMute", "VolumeUp",
+	}
+
+	// browserOnlyNames represents the keys that GLFW doesn't have.
+	browserOnlyNames = map[string]struct{}{
+		"MediaPlayPause":     {},
+		"MediaStop":          {},
+		"MediaTrackNext":     {},
+		"MediaTrackPrevious": {},
+		"VolumeDown":         {},
+		"VolumeMute":         {},
+		"VolumeUp":           {},
+	}
 )
 
 func init() {
@@ -68,6 +102,37 @@ func init() {
 		"Backslash":    {"Backslash"},
 		"RightBracket": {"BracketRight"},
 		"GraveAccent":  {"Backquote"},
+
+		"LeftAlt":      {"AltLeft"},
+		"RightAlt":     {"AltRight"},
+		"LeftControl":  {"ControlLeft"},
+		"RightControl": {"ControlRight"},
+		"LeftShift":    {"ShiftLeft"},
+		"RightShift":   {"ShiftRight"},
+		"LeftSuper":    {"MetaLeft", "OSLeft"},
+		"RightSuper":   {"MetaRight", "OSRight"},
+
+		"Menu":        {"ContextMenu"},
+		"NumLock":     {"NumLock"},
+		"Pause":       {"Pause"},
+		"PrintScreen": {"PrintScreen"},
+		"ScrollLock":  {"ScrollLock"},
+
+		"KPDecimal":  {"NumpadDecimal"},
+		"KPDivide":   {"NumpadDivide"},
+		"KPMultiply": {"NumpadMultiply"},
+		"KPSubtract": {"NumpadSubtract"},
+		"KPAdd":      {"NumpadAdd"},
+		"KPEnter":    {"NumpadEnter"},
+		"KPEqual":    {"NumpadEqual"},
+
+		"MediaPlayPause":     {"MediaPlayPause"},
+		"MediaStop":          {"MediaStop"},
+		"MediaTrackNext":     {"MediaTrackNext"},
+		"MediaTrackPrevious": {"MediaTrackPrevious"},
+		"VolumeDown":         {"AudioVolumeDown", "VolumeDown"},
+		"VolumeMute":         {"AudioVolumeMute", "VolumeMute"},
+		"VolumeUp":           {"AudioVolumeUp", "VolumeUp"},
 	}
 	// ASCII: 0 - 9
 	for c := '0'; c <= '9'; c++ {
@@ -78,9 +143,13 @@ func init() {
 		nameToCodes[string(c)] = []string{"Key" + string(c)}
 	}
 	// Function keys
-	for i := 1; i <= 12; i++ {
+	for i := 1; i <= 24; i++ {
 		nameToCodes["F"+strconv.Itoa(i)] = []string{"F" + strconv.Itoa(i)}
 	}
+	// Numpad
+	for c := '0'; c <= '9'; c++ {
+		nameToCodes["KP"+string(c)] = []string{"Numpad" + string(c)}
+	}
 }
 
 func init() {
@@ -115,6 +184,25 @@ func init() {
 		0xdd: "RightBracket",
 		0xc0: "GraveAccent",
 		0x08: "Backspace",
+		0x5B: "LeftSuper",
+		0x5C: "RightSuper",
+		0x5D: "Menu",
+		0x90: "NumLock",
+		0x13: "Pause",
+		0x2C: "PrintScreen",
+		0x91: "ScrollLock",
+		0x6E: "KPDecimal",
+		0x6F: "KPDivide",
+		0x6A: "KPMultiply",
+		0x6D: "KPSubtract",
+		0x6B: "KPAdd",
+		0xB3: "MediaPlayPause",
+		0xB2: "MediaStop",
+		0xB0: "MediaTrackNext",
+		0xB1: "MediaTrackPrevious",
+		0xAE: "VolumeDown",
+		0xAD: "VolumeMute",
+		0xAF: "VolumeUp",
 	}
 	// ASCII: 0 - 9
 	for c := '0'; c <= '9'; c++ {
@@ -125,9 +213,13 @@ func init() {
 		keyCodeToNameEdge[int(c)] = string(c)
 	}
 	// Function keys
-	for i := 1; i <= 12; i++ {
+	for i := 1; i <= 24; i++ {
 		keyCodeToNameEdge[0x70+i-1] = "F" + strconv.Itoa(i)
 	}
+	// Numpad
+	for c := '0'; c <= '9'; c++ {
+		keyCodeToNameEdge[0x60+int(c-'0')] = "KP" + string(c)
+	}
 }
 
 const ebitenKeysTmpl = `{{.License}}
@@ -137,12 +229,20 @@ const ebitenKeysTmpl = `{{.License}}
 package ebiten
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/dave/ebiten/internal/ui"
 )
 
 // A Key represents a keyboard key.
 // These keys represent pysical keys of US keyboard.
 // For example, KeyQ represents Q key on US keyboards and ' (quote) key on Dvorak keyboards.
+// Use KeyName to get the name of a key on the user's keyboard layout.
+//
+// KeyAlt, KeyControl and KeyShift represent either of the left and right keys.
+//
+// The media keys like KeyMediaPlayPause and KeyVolumeUp are available only on browsers.
 type Key int
 
 // Keys
@@ -150,6 +250,46 @@ const (
 {{range $index, $name := .KeyNames}}Key{{$name}} Key = Key(ui.Key{{$name}})
 {{end}}	KeyMax Key = Key{{.LastKeyName}}
 )
+
+// String returns the name of the key without the "Key" prefix, like "A" or "LeftShift".
+// The name doesn't depend on the keyboard layout: use KeyName to get the name on the user's layout.
+//
+// If k is not a defined key, String returns an empty string.
+func (k Key) String() string {
+	switch k {
+	{{range $index, $name := .KeyNames}}case Key{{$name}}:
+		return "{{$name}}"
+	{{end}}}
+	return ""
+}
+
+func keyNameToKey(name string) (Key, bool) {
+	switch strings.ToLower(name) {
+	{{range $index, $name := .KeyNames}}case "{{$name | ToLower}}":
+		return Key{{$name}}, true
+	{{end}}}
+	return 0, false
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k Key) MarshalText() ([]byte, error) {
+	s := k.String()
+	if s == "" {
+		return nil, fmt.Errorf("ebiten: undefined key: %d", int(k))
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The name is case-insensitive.
+func (k *Key) UnmarshalText(text []byte) error {
+	key, ok := keyNameToKey(string(text))
+	if !ok {
+		return fmt.Errorf("ebiten: unexpected key name: %s", string(text))
+	}
+	*k = key
+	return nil
+}
 `
 
 const uiKeysTmpl = `{{.License}}
@@ -164,6 +304,18 @@ const (
 {{range $index, $name := .KeyNames}}Key{{$name}}{{if eq $index 0}} Key = iota{{end}}
 {{end}}
 )
+
+var keyNames = map[Key]string{
+{{range $index, $name := .KeyNames}}Key{{$name}}: "{{$name}}",
+{{end}}
+}
+
+var modKeyToSideKeys = map[Key][]Key{
+{{range $name, $sides := .ModNameToSideNames}}Key{{$name}}: {
+{{range $side := $sides}}Key{{$side}},{{end}}
+},
+{{end}}
+}
 `
 
 const uiKeysGlfwTmpl = `{{.License}}
@@ -175,18 +327,12 @@ const uiKeysGlfwTmpl = `{{.License}}
 package ui
 
 import (
-	glfw "github.com/go-gl/glfw/v3.2/glfw"
+	glfw "github.com/go-gl/glfw/v3.3/glfw"
 )
 
 var glfwKeyCodeToKey = map[glfw.Key]Key{
-{{range $index, $name := .KeyNamesWithoutMods}}glfw.Key{{$name}}: Key{{$name}},
+{{range $index, $name := .GLFWKeyNames}}glfw.Key{{$name}}: Key{{$name}},
 {{end}}
-	glfw.KeyLeftAlt:      KeyAlt,
-	glfw.KeyRightAlt:     KeyAlt,
-	glfw.KeyLeftControl:  KeyControl,
-	glfw.KeyRightControl: KeyControl,
-	glfw.KeyLeftShift:    KeyShift,
-	glfw.KeyRightShift:   KeyShift,
 }
 `
 
@@ -199,7 +345,7 @@ const uiKeysJSTmpl = `{{.License}}
 package ui
 
 var keyToCodes = map[Key][]string{
-{{range $name, $codes := .NameToCodes}}Key{{$name}}: []string{
+{{range $name, $codes := .NameToCodes}}Key{{$name}}: {
 {{range $code := $codes}}"{{$code}}",{{end}}
 },
 {{end}}
@@ -297,27 +443,34 @@ func main() {
 
 	notice := "DO NOT EDIT: This file is auto-generated by genkeys.go."
 
-	namesSet := map[string]struct{}{}
-	namesWithoutModsSet := map[string]struct{}{}
+	appended := map[string]struct{}{}
+	for _, name := range appendedNames {
+		if _, ok := nameToCodes[name]; !ok {
+			log.Fatalf("unknown key name: %s", name)
+		}
+		appended[name] = struct{}{}
+	}
+
+	names := []string{}
+	glfwNames := []string{}
 	codes := []string{}
 	for name, cs := range nameToCodes {
-		namesSet[name] = struct{}{}
+		if _, ok := appended[name]; !ok {
+			names = append(names, name)
+		}
 		codes = append(codes, cs...)
-		if name != "Alt" && name != "Control" && name != "Shift" {
-			namesWithoutModsSet[name] = struct{}{}
+		if _, ok := modNameToSideNames[name]; ok {
+			continue
 		}
-	}
-	names := []string{}
-	namesWithoutMods := []string{}
-	for n := range namesSet {
-		names = append(names, n)
-	}
-	for n := range namesWithoutModsSet {
-		namesWithoutMods = append(namesWithoutMods, n)
+		if _, ok := browserOnlyNames[name]; ok {
+			continue
+		}
+		glfwNames = append(glfwNames, name)
 	}
 
 	sort.Sort(KeyNames(names))
-	sort.Sort(KeyNames(namesWithoutMods))
+	names = append(names, appendedNames...)
+	sort.Sort(KeyNames(glfwNames))
 	sort.Strings(codes)
 
 	for path, tmpl := range map[string]string{
@@ -331,7 +484,10 @@ func main() {
 			log.Fatal(err)
 		}
 		defer f.Close()
-		tmpl, err := template.New(path).Parse(tmpl)
+		funcs := template.FuncMap{
+			"ToLower": strings.ToLower,
+		}
+		tmpl, err := template.New(path).Funcs(funcs).Parse(tmpl)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -349,15 +505,16 @@ func main() {
 		}
 		// NOTE: According to godoc, maps are automatically sorted by key.
 		if err := tmpl.Execute(f, map[string]interface{}{
-			"License":             license,
-			"Notice":              notice,
-			"BuildTag":            buildTag,
-			"NameToCodes":         nameToCodes,
-			"KeyCodeToNameEdge":   keyCodeToNameEdge,
-			"Codes":               codes,
-			"KeyNames":            names,
-			"LastKeyName":         names[len(names)-1],
-			"KeyNamesWithoutMods": namesWithoutMods,
+			"License":            license,
+			"Notice":             notice,
+			"BuildTag":           buildTag,
+			"NameToCodes":        nameToCodes,
+			"KeyCodeToNameEdge":  keyCodeToNameEdge,
+			"Codes":              codes,
+			"ModNameToSideNames": modNameToSideNames,
+			"KeyNames":           names,
+			"LastKeyName":        names[len(names)-1],
+			"GLFWKeyNames":       glfwNames,
 		}); err != nil {
 			log.Fatal(err)
 		}
@@ -0,0 +1,377 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gesture provides functions to recognize touch gestures like taps, swipes and pinches.
+//
+// The gestures are recognized from the touch states of ebiten.Touches every tick,
+// i.e., every call of the update function passed to ebiten.Run, before the update function is called.
+// The positions and the distances are in logical screen coordinates, and the durations are in ticks.
+//
+// Note: This package is experimental and API might be changed.
+package gesture
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/internal/hooks"
+	"github.com/dave/ebiten/internal/sync"
+)
+
+// Type represents a type of a gesture.
+type Type int
+
+// Gesture types
+const (
+	// Tap is a short touch without moving.
+	Tap Type = iota
+
+	// DoubleTap is a tap following a tap at nearly the same position.
+	// A DoubleTap event is emitted in the same tick as the second Tap event.
+	DoubleTap
+
+	// LongPress is a touch staying at nearly the same position for a while.
+	// A LongPress event is emitted once while the touch is still pressed,
+	// and no Tap event is emitted for the touch.
+	LongPress
+
+	// Swipe is a touch moving quickly and being released.
+	Swipe
+
+	// Pinch is a change of the distance between two touches.
+	// Pinch events are emitted in every tick while the distance changes.
+	Pinch
+
+	// Rotate is a change of the angle between two touches.
+	// Rotate events are emitted in every tick while the angle changes.
+	Rotate
+)
+
+// String returns the name of the gesture type.
+func (t Type) String() string {
+	switch t {
+	case Tap:
+		return "Tap"
+	case DoubleTap:
+		return "DoubleTap"
+	case LongPress:
+		return "LongPress"
+	case Swipe:
+		return "Swipe"
+	case Pinch:
+		return "Pinch"
+	case Rotate:
+		return "Rotate"
+	}
+	return "Unknown"
+}
+
+// Event represents a recognized gesture.
+type Event struct {
+	// Type is the type of the gesture.
+	Type Type
+
+	// X and Y are the position of the gesture.
+	// For Tap, DoubleTap and LongPress, the position is where the touch is pressed.
+	// For Swipe, the position is where the touch is released.
+	// For Pinch and Rotate, the position is the midpoint of the two touches.
+	X float64
+	Y float64
+
+	// DX and DY are the distance that the touch moves from the start for Swipe.
+	DX float64
+	DY float64
+
+	// VX and VY are the velocity of the touch when the touch is released in pixels per second for Swipe.
+	VX float64
+	VY float64
+
+	// Scale is the ratio of the current distance between the two touches to the distance in the previous tick for Pinch.
+	// A value greater than 1 means zooming in.
+	Scale float64
+
+	// Angle is the change of the angle of the line between the two touches from the previous tick
+	// in radians for Rotate.
+	// A positive value means clockwise rotation on the screen.
+	Angle float64
+}
+
+const (
+	// tapMaxDistance is the maximum distance that a touch can move as a tap or a long press.
+	tapMaxDistance = 10
+
+	// tapMaxDuration is the maximum duration of a tap in ticks.
+	tapMaxDuration = ebiten.FPS / 4
+
+	// doubleTapMaxInterval is the maximum interval between the two taps of a double tap in ticks.
+	doubleTapMaxInterval = ebiten.FPS / 3
+
+	// doubleTapMaxDistance is the maximum distance between the two taps of a double tap.
+	doubleTapMaxDistance = 30
+
+	// longPressDuration is the duration of a long press in ticks.
+	longPressDuration = ebiten.FPS / 2
+
+	// swipeMinDistance is the minimum distance that a touch moves as a swipe.
+	swipeMinDistance = 30
+
+	// swipeMaxDuration is the maximum duration of a swipe in ticks.
+	swipeMaxDuration = ebiten.FPS
+
+	// velocityTicks is the number of the ticks to calculate the velocity of a swipe.
+	velocityTicks = 5
+)
+
+type point struct {
+	x float64
+	y float64
+}
+
+func distance(a, b point) float64 {
+	return math.Hypot(b.x-a.x, b.y-a.y)
+}
+
+type touch struct {
+	start       point
+	startTick   int
+	history     []point
+	moved       bool
+	multi       bool
+	longPressed bool
+}
+
+func (t *touch) current() point {
+	return t.history[len(t.history)-1]
+}
+
+type state struct {
+	tick        int
+	touches     map[int]*touch
+	events      []Event
+	lastTap     point
+	lastTapTick int
+	hasLastTap  bool
+
+	m sync.RWMutex
+}
+
+var theState = &state{
+	touches: map[int]*touch{},
+}
+
+func init() {
+	hooks.AppendHookOnUpdate(func() error {
+		theState.update()
+		return nil
+	})
+}
+
+func (s *state) update() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.tick++
+	s.events = s.events[:0]
+
+	current := map[int]point{}
+	for _, t := range ebiten.Touches() {
+		x, y := t.Position()
+		current[t.ID()] = point{float64(x), float64(y)}
+	}
+
+	// Released touches
+	var released []int
+	for id := range s.touches {
+		if _, ok := current[id]; !ok {
+			released = append(released, id)
+		}
+	}
+	sort.Ints(released)
+	for _, id := range released {
+		s.release(s.touches[id])
+		delete(s.touches, id)
+	}
+
+	// Pinch and rotate are recognized only with the two touches existing in the previous tick.
+	var pairIDs []int
+	var prev0, prev1 point
+	if len(s.touches) == 2 && len(current) == 2 {
+		for id := range s.touches {
+			pairIDs = append(pairIDs, id)
+		}
+		sort.Ints(pairIDs)
+		prev0 = s.touches[pairIDs[0]].current()
+		prev1 = s.touches[pairIDs[1]].current()
+	}
+
+	// Pressed touches
+	ids := make([]int, 0, len(current))
+	for id := range current {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		p := current[id]
+		t, ok := s.touches[id]
+		if !ok {
+			t = &touch{
+				start:     p,
+				startTick: s.tick,
+			}
+			s.touches[id] = t
+		}
+		t.history = append(t.history, p)
+		if len(t.history) > velocityTicks+1 {
+			t.history = t.history[len(t.history)-velocityTicks-1:]
+		}
+		if distance(t.start, p) > tapMaxDistance {
+			t.moved = true
+		}
+	}
+	if len(s.touches) >= 2 {
+		for _, t := range s.touches {
+			t.multi = true
+		}
+	}
+
+	// Long presses
+	for _, id := range ids {
+		t := s.touches[id]
+		if t.multi || t.moved || t.longPressed {
+			continue
+		}
+		if s.tick-t.startTick+1 < longPressDuration {
+			continue
+		}
+		t.longPressed = true
+		s.events = append(s.events, Event{
+			Type: LongPress,
+			X:    t.start.x,
+			Y:    t.start.y,
+		})
+	}
+
+	if pairIDs != nil {
+		s.recognizePair(prev0, prev1, current[pairIDs[0]], current[pairIDs[1]])
+	}
+}
+
+func (s *state) release(t *touch) {
+	if t.multi || t.longPressed {
+		return
+	}
+	duration := s.tick - t.startTick
+	p := t.current()
+
+	if !t.moved {
+		if duration > tapMaxDuration {
+			return
+		}
+		s.events = append(s.events, Event{
+			Type: Tap,
+			X:    t.start.x,
+			Y:    t.start.y,
+		})
+		if s.hasLastTap && s.tick-s.lastTapTick <= doubleTapMaxInterval && distance(s.lastTap, t.start) <= doubleTapMaxDistance {
+			s.events = append(s.events, Event{
+				Type: DoubleTap,
+				X:    t.start.x,
+				Y:    t.start.y,
+			})
+			// A third tap starts a new double tap.
+			s.hasLastTap = false
+			return
+		}
+		s.lastTap = t.start
+		s.lastTapTick = s.tick
+		s.hasLastTap = true
+		return
+	}
+
+	if duration > swipeMaxDuration {
+		return
+	}
+	if distance(t.start, p) < swipeMinDistance {
+		return
+	}
+	oldest := t.history[0]
+	n := float64(len(t.history) - 1)
+	var vx, vy float64
+	if n > 0 {
+		vx = (p.x - oldest.x) / n * ebiten.FPS
+		vy = (p.y - oldest.y) / n * ebiten.FPS
+	}
+	s.events = append(s.events, Event{
+		Type: Swipe,
+		X:    p.x,
+		Y:    p.y,
+		DX:   p.x - t.start.x,
+		DY:   p.y - t.start.y,
+		VX:   vx,
+		VY:   vy,
+	})
+}
+
+func (s *state) recognizePair(prev0, prev1, current0, current1 point) {
+	cx := (current0.x + current1.x) / 2
+	cy := (current0.y + current1.y) / 2
+
+	prevDist := distance(prev0, prev1)
+	currentDist := distance(current0, current1)
+	if prevDist > 0 && currentDist > 0 && prevDist != currentDist {
+		s.events = append(s.events, Event{
+			Type:  Pinch,
+			X:     cx,
+			Y:     cy,
+			Scale: currentDist / prevDist,
+		})
+	}
+
+	if prevDist == 0 || currentDist == 0 {
+		return
+	}
+	prevAngle := math.Atan2(prev1.y-prev0.y, prev1.x-prev0.x)
+	currentAngle := math.Atan2(current1.y-current0.y, current1.x-current0.x)
+	a := currentAngle - prevAngle
+	for a > math.Pi {
+		a -= 2 * math.Pi
+	}
+	for a <= -math.Pi {
+		a += 2 * math.Pi
+	}
+	if a == 0 {
+		return
+	}
+	s.events = append(s.events, Event{
+		Type:  Rotate,
+		X:     cx,
+		Y:     cy,
+		Angle: a,
+	})
+}
+
+// Events returns the gestures recognized in the current tick.
+//
+// The events are ordered by the time they are recognized: the gestures of released touches come first.
+//
+// This function is concurrent-safe.
+func Events() []Event {
+	theState.m.RLock()
+	defer theState.m.RUnlock()
+	if len(theState.events) == 0 {
+		return nil
+	}
+	return append(make([]Event, 0, len(theState.events)), theState.events...)
+}
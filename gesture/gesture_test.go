@@ -0,0 +1,136 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gesture_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/dave/ebiten/gesture"
+	"github.com/dave/ebiten/inputtest"
+)
+
+func update(t *testing.T) []Event {
+	var events []Event
+	if err := inputtest.Update(func() error {
+		events = Events()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func types(events []Event) []Type {
+	var ts []Type
+	for _, e := range events {
+		ts = append(ts, e.Type)
+	}
+	return ts
+}
+
+func TestTap(t *testing.T) {
+	defer inputtest.Reset()
+
+	inputtest.SetTouch(1, 10, 20)
+	if got := update(t); len(got) != 0 {
+		t.Errorf("got: %v, want: no events", types(got))
+	}
+	inputtest.ReleaseTouch(1)
+	got := update(t)
+	if len(got) != 1 || got[0].Type != Tap || got[0].X != 10 || got[0].Y != 20 {
+		t.Errorf("got: %v, want: a Tap at (10, 20)", got)
+	}
+
+	inputtest.SetTouch(2, 12, 22)
+	update(t)
+	inputtest.ReleaseTouch(2)
+	got = update(t)
+	if len(got) != 2 || got[0].Type != Tap || got[1].Type != DoubleTap {
+		t.Errorf("got: %v, want: [Tap DoubleTap]", types(got))
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	defer inputtest.Reset()
+
+	inputtest.SetTouch(1, 10, 20)
+	n := 0
+	for i := 0; i < 60; i++ {
+		for _, e := range update(t) {
+			if e.Type != LongPress {
+				t.Errorf("got: %v, want: LongPress", e.Type)
+			}
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("the number of LongPress: got: %d, want: 1", n)
+	}
+	inputtest.ReleaseTouch(1)
+	if got := update(t); len(got) != 0 {
+		t.Errorf("got: %v, want: no events", types(got))
+	}
+}
+
+func TestSwipe(t *testing.T) {
+	defer inputtest.Reset()
+
+	for i := 0; i < 5; i++ {
+		inputtest.SetTouch(1, 10+i*20, 20)
+		update(t)
+	}
+	inputtest.ReleaseTouch(1)
+	got := update(t)
+	if len(got) != 1 || got[0].Type != Swipe {
+		t.Fatalf("got: %v, want: [Swipe]", types(got))
+	}
+	e := got[0]
+	if e.DX != 80 || e.DY != 0 {
+		t.Errorf("(DX, DY): got: (%f, %f), want: (80, 0)", e.DX, e.DY)
+	}
+	if e.VX != 20*60 || e.VY != 0 {
+		t.Errorf("(VX, VY): got: (%f, %f), want: (1200, 0)", e.VX, e.VY)
+	}
+}
+
+func TestPinchAndRotate(t *testing.T) {
+	defer inputtest.Reset()
+
+	inputtest.SetTouch(1, 100, 100)
+	inputtest.SetTouch(2, 200, 100)
+	update(t)
+
+	inputtest.SetTouch(1, 50, 100)
+	inputtest.SetTouch(2, 250, 100)
+	got := update(t)
+	if len(got) != 1 || got[0].Type != Pinch || got[0].Scale != 2 || got[0].X != 150 || got[0].Y != 100 {
+		t.Errorf("got: %v, want: a Pinch with scale 2 at (150, 100)", got)
+	}
+
+	inputtest.SetTouch(1, 150, 0)
+	inputtest.SetTouch(2, 150, 200)
+	got = update(t)
+	if len(got) != 1 || got[0].Type != Rotate || math.Abs(got[0].Angle-math.Pi/2) > 1e-9 {
+		t.Errorf("got: %v, want: a Rotate with angle π/2", got)
+	}
+
+	// No tap is recognized for the touches of a multi-touch gesture.
+	inputtest.ReleaseTouch(1)
+	inputtest.ReleaseTouch(2)
+	if got := update(t); len(got) != 0 {
+		t.Errorf("got: %v, want: no events", types(got))
+	}
+}
@@ -112,6 +112,9 @@ func (c *graphicsContext) Update(afterFrameUpdate func()) error {
 		if err := c.f(c.offscreen); err != nil {
 			return err
 		}
+		if err := hooks.RunAfterUpdate(); err != nil {
+			return err
+		}
 		afterFrameUpdate()
 	}
 	if 0 < updateCount {
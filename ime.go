@@ -0,0 +1,74 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/dave/ebiten/internal/ui"
+)
+
+// IMEComposition returns the text being composed by the input method editor (IME)
+// and the caret position in the text, counted in runes.
+//
+// IMEComposition returns an empty text while no text is being composed.
+// When a composition is committed, the committed text is reported by InputChars and
+// IMEComposition returns an empty text again.
+//
+// IMEComposition is useful to render the composition in the game's own text field,
+// e.g., for players typing Japanese, Chinese or Korean.
+//
+// IMEComposition works only on browsers and Windows.
+// On browsers, the composition is available only while the IME is enabled by SetIMEEnabled.
+// On Windows, the composition is read from the IME of the system.
+// On macOS and Linux, the IME renders the composition in its own window by itself and
+// IMEComposition always returns an empty text: only the committed text is reported by InputChars.
+//
+// This function is concurrent-safe.
+//
+// This function always returns an empty text on mobiles.
+func IMEComposition() (text string, caret int) {
+	return ui.IMEComposition()
+}
+
+// SetIMEEnabled sets whether the IME is enabled.
+//
+// On browsers, the keyboard events are sent to a hidden input element instead of the canvas
+// while the IME is enabled, so that the IME can work with the game.
+// The IME is disabled by default: enable the IME only while the player is typing text,
+// otherwise keys like WASD might be consumed by the IME.
+//
+// SetIMEEnabled works only on browsers.
+// On desktops including Windows, SetIMEEnabled does nothing and the IME is always available as the system provides.
+//
+// This function is concurrent-safe.
+//
+// This function does nothing on mobiles.
+func SetIMEEnabled(enabled bool) {
+	ui.SetIMEEnabled(enabled)
+}
+
+// SetIMECandidateWindowPosition sets the position where the IME shows the candidate window
+// in logical screen coordinates.
+//
+// Typically the position is the bottom-left corner of the caret in the game's text field.
+//
+// On browsers and Windows, the candidate window is shown around the position.
+// On macOS and Linux, SetIMECandidateWindowPosition does nothing and the IME decides the position by itself.
+//
+// This function is concurrent-safe.
+//
+// This function does nothing on mobiles.
+func SetIMECandidateWindowPosition(x, y int) {
+	ui.SetIMECandidateWindowPosition(x, y)
+}
@@ -15,6 +15,9 @@
 package ebiten
 
 import (
+	"io"
+
+	"github.com/dave/ebiten/internal/gamepaddb"
 	"github.com/dave/ebiten/internal/ui"
 )
 
@@ -39,6 +42,27 @@ func IsKeyPressed(key Key) bool {
 	return ui.CurrentInput().IsKeyPressed(ui.Key(key))
 }
 
+// KeyName returns the name of the key (key) on the user's current keyboard layout.
+//
+// For a printable key, KeyName returns the character that the key produces without modifiers in upper case.
+// For example, KeyName(KeyQ) returns "A" on AZERTY keyboards.
+// For the other keys, or when the layout is not available, KeyName returns the name of the key
+// without the "Key" prefix, like "Enter" or "LeftShift".
+// The numpad keys are always named in this way, like "KP1".
+//
+// KeyName is useful to show key bindings to the user.
+//
+// On desktops, the layout is not available before the game starts.
+// On browsers, the layout is available when the browser implements the Keyboard API.
+// Otherwise, the character of a key is known after the key is typed.
+//
+// This function is concurrent-safe.
+//
+// This function always returns the name of the key without the layout on mobiles.
+func KeyName(key Key) string {
+	return ui.KeyName(ui.Key(key))
+}
+
 // CursorPosition returns a position of a mouse cursor.
 //
 // This function is concurrent-safe.
@@ -46,6 +70,20 @@ func CursorPosition() (x, y int) {
 	return ui.CurrentInput().CursorPosition()
 }
 
+// Wheel returns the x and y offsets of the mouse wheel or touchpad scroll.
+// It returns (0, 0) if the wheel isn't being rolled.
+//
+// The offsets are accumulated since the previous frame.
+// One notch of a typical mouse wheel is 1.
+// A positive yoff means scrolling up, and a positive xoff means scrolling left.
+//
+// This function is concurrent-safe.
+//
+// This function always returns (0, 0) on mobiles.
+func Wheel() (xoff, yoff float64) {
+	return ui.CurrentInput().Wheel()
+}
+
 // IsMouseButtonPressed returns a boolean indicating whether mouseButton is pressed.
 //
 // This function is concurrent-safe.
@@ -65,6 +103,32 @@ func GamepadIDs() []int {
 	return ui.CurrentInput().GamepadIDs()
 }
 
+// GamepadSDLID returns a string with the GUID of the gamepad (id) generated in the same way as SDL.
+// The GUID is stable for the same model of gamepads across reconnections,
+// and can be used to look up SDL_GameControllerDB.
+//
+// On browsers, the GUID is generated from the vendor and the product in the gamepad ID if available.
+// GamepadSDLID returns an empty string if the GUID is not available.
+//
+// This function is concurrent-safe.
+//
+// This function always returns an empty string on mobiles.
+func GamepadSDLID(id int) string {
+	return ui.CurrentInput().GamepadSDLID(id)
+}
+
+// GamepadName returns the name of the gamepad (id) reported by the system.
+//
+// On browsers, GamepadName returns the ID string of the Gamepad API, which might include the vendor and the product.
+// GamepadName returns an empty string if the gamepad is not connected.
+//
+// This function is concurrent-safe.
+//
+// This function always returns an empty string on mobiles.
+func GamepadName(id int) string {
+	return ui.CurrentInput().GamepadName(id)
+}
+
 // GamepadAxisNum returns the number of axes of the gamepad (id).
 //
 // This function is concurrent-safe.
@@ -105,6 +169,67 @@ func IsGamepadButtonPressed(id int, button GamepadButton) bool {
 	return ui.CurrentInput().IsGamepadButtonPressed(id, ui.GamepadButton(button))
 }
 
+// IsStandardGamepadLayoutAvailable reports whether the gamepad (id) has a mapping to the standard layout.
+//
+// On desktops, the mapping is looked up by the gamepad's GUID in the embedded SDL_GameControllerDB
+// (https://github.com/gabomdq/SDL_GameControllerDB) and the mappings added by UpdateStandardGamepadLayoutMappings.
+// On browsers, the standard layout is available when the browser provides the standard mapping,
+// or when a mapping without a platform field is added by UpdateStandardGamepadLayoutMappings.
+// The embedded database is not used on browsers since all of its mappings are for specific platforms.
+//
+// Even if IsStandardGamepadLayoutAvailable returns false, the functions for the standard layout
+// work with the raw indices: e.g., StandardGamepadButtonA is treated as GamepadButton0.
+//
+// This function is concurrent-safe.
+//
+// This function always returns false on mobiles.
+func IsStandardGamepadLayoutAvailable(id int) bool {
+	return ui.CurrentInput().IsStandardGamepadLayoutAvailable(id)
+}
+
+// StandardGamepadAxisValue returns the float value [-1.0 - 1.0] of the given gamepad (id)'s axis (axis) in the standard layout.
+//
+// This function is concurrent-safe.
+//
+// This function always returns 0 on mobiles.
+func StandardGamepadAxisValue(id int, axis StandardGamepadAxis) float64 {
+	return ui.CurrentInput().StandardGamepadAxisValue(id, gamepaddb.StandardAxis(axis))
+}
+
+// StandardGamepadButtonValue returns the float value [0.0 - 1.0] of the given gamepad (id)'s button (button) in the standard layout.
+//
+// StandardGamepadButtonValue is useful for analog buttons like triggers.
+// For digital buttons, the value is 0 or 1.
+//
+// This function is concurrent-safe.
+//
+// This function always returns 0 on mobiles.
+func StandardGamepadButtonValue(id int, button StandardGamepadButton) float64 {
+	return ui.CurrentInput().StandardGamepadButtonValue(id, gamepaddb.StandardButton(button))
+}
+
+// IsStandardGamepadButtonPressed returns the boolean indicating the given button of the gamepad (id) in the standard layout is pressed or not.
+//
+// This function is concurrent-safe.
+//
+// This function always returns false on mobiles.
+func IsStandardGamepadButtonPressed(id int, button StandardGamepadButton) bool {
+	return ui.CurrentInput().IsStandardGamepadButtonPressed(id, gamepaddb.StandardButton(button))
+}
+
+// UpdateStandardGamepadLayoutMappings adds gamepad mappings in the format of SDL_GameControllerDB.
+//
+// mappings is the content of a file like gamecontrollerdb.txt.
+// Lines for other platforms are ignored, and an existing mapping with the same GUID is replaced.
+// On browsers, only lines without the platform field are used.
+// If mappings has an invalid line, UpdateStandardGamepadLayoutMappings returns an error
+// and no mapping is added.
+//
+// This function is concurrent-safe.
+func UpdateStandardGamepadLayoutMappings(mappings string) error {
+	return gamepaddb.Update([]byte(mappings))
+}
+
 // Touch represents a touch state.
 type Touch interface {
 	// ID returns an identifier for one stroke.
@@ -116,7 +241,7 @@ type Touch interface {
 
 // Touches returns the current touch states.
 //
-// Touches always returns nil on desktops.
+// Touches always returns nil on desktops unless the touch emulation is enabled by SetTouchEmulationEnabled.
 func Touches() []Touch {
 	t := ui.CurrentInput().Touches()
 	tt := make([]Touch, len(t))
@@ -125,3 +250,66 @@ func Touches() []Touch {
 	}
 	return tt
 }
+
+// SetTouchEmulationEnabled sets whether the left mouse button emulates a touch.
+//
+// While the touch emulation is enabled and the left mouse button is pressed,
+// Touches includes a touch at the cursor position with the ID -1.
+// This is useful to develop and test the input handling for mobiles on desktops.
+// The mouse functions like IsMouseButtonPressed are not affected.
+// The input events by InputEvents are not affected either.
+//
+// The touch emulation is disabled by default.
+//
+// This function is concurrent-safe.
+//
+// SetTouchEmulationEnabled does nothing on mobiles.
+func SetTouchEmulationEnabled(enabled bool) {
+	ui.CurrentInput().SetTouchEmulationEnabled(enabled)
+}
+
+// IsTouchEmulationEnabled reports whether the left mouse button emulates a touch.
+//
+// This function is concurrent-safe.
+func IsTouchEmulationEnabled() bool {
+	return ui.CurrentInput().IsTouchEmulationEnabled()
+}
+
+// DroppedFile represents a file dropped onto the window.
+type DroppedFile interface {
+	// Name returns the name of the file.
+	//
+	// On desktops, Name returns the path of the file.
+	// On browsers, Name returns only the file name without the directory.
+	Name() string
+
+	// Position returns the position where the file is dropped in logical screen coordinates.
+	//
+	// On desktops, the position is the cursor position when the file is dropped.
+	Position() (x, y int)
+
+	// Open opens the file to read its content.
+	//
+	// On browsers, the content is already read into memory when the file is reported.
+	Open() (io.ReadCloser, error)
+}
+
+// DroppedFiles returns the files dropped onto the window in the current frame.
+//
+// On browsers, the files are reported after their contents are read,
+// which might be a few frames after the files are dropped.
+//
+// This function is concurrent-safe.
+//
+// DroppedFiles always returns nil on mobiles.
+func DroppedFiles() []DroppedFile {
+	f := ui.CurrentInput().DroppedFiles()
+	if len(f) == 0 {
+		return nil
+	}
+	ff := make([]DroppedFile, len(f))
+	for i := 0; i < len(ff); i++ {
+		ff[i] = f[i]
+	}
+	return ff
+}
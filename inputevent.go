@@ -0,0 +1,124 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"time"
+
+	"github.com/dave/ebiten/internal/ui"
+)
+
+// An InputEventType represents the type of an input event.
+type InputEventType int
+
+// InputEventTypes
+const (
+	InputEventTypeKeyDown         InputEventType = InputEventType(ui.InputEventTypeKeyDown)
+	InputEventTypeKeyUp           InputEventType = InputEventType(ui.InputEventTypeKeyUp)
+	InputEventTypeChar            InputEventType = InputEventType(ui.InputEventTypeChar)
+	InputEventTypeMouseButtonDown InputEventType = InputEventType(ui.InputEventTypeMouseButtonDown)
+	InputEventTypeMouseButtonUp   InputEventType = InputEventType(ui.InputEventTypeMouseButtonUp)
+	InputEventTypeMouseMove       InputEventType = InputEventType(ui.InputEventTypeMouseMove)
+	InputEventTypeWheel           InputEventType = InputEventType(ui.InputEventTypeWheel)
+	InputEventTypeTouchStart      InputEventType = InputEventType(ui.InputEventTypeTouchStart)
+	InputEventTypeTouchMove       InputEventType = InputEventType(ui.InputEventTypeTouchMove)
+	InputEventTypeTouchEnd        InputEventType = InputEventType(ui.InputEventTypeTouchEnd)
+)
+
+// An InputEvent represents an input event.
+type InputEvent struct {
+	// Type is the type of the event.
+	Type InputEventType
+
+	// Time is the timestamp of the event.
+	//
+	// The origin of Time is unspecified and might differ among platforms.
+	// Use the difference between the times of events.
+	Time time.Duration
+
+	// Key is the key for InputEventTypeKeyDown and InputEventTypeKeyUp.
+	//
+	// For the modifier keys, Key is the key with the left/right distinction like KeyLeftShift.
+	Key Key
+
+	// Char is the character for InputEventTypeChar.
+	Char rune
+
+	// MouseButton is the mouse button for InputEventTypeMouseButtonDown and InputEventTypeMouseButtonUp.
+	MouseButton MouseButton
+
+	// TouchID is the ID of the touch for InputEventTypeTouchStart, InputEventTypeTouchMove and InputEventTypeTouchEnd.
+	TouchID int
+
+	// X and Y are the position of the event in logical screen coordinates.
+	//
+	// For the mouse and wheel events, the position is the cursor position.
+	// For the touch events, the position is the touch position.
+	X int
+	Y int
+
+	// WheelX and WheelY are the offsets for InputEventTypeWheel.
+	// See Wheel for the units.
+	WheelX float64
+	WheelY float64
+}
+
+// SetInputEventsEnabled sets whether the input events are recorded for InputEvents.
+//
+// The input events are not recorded by default.
+//
+// This function is concurrent-safe.
+func SetInputEventsEnabled(enabled bool) {
+	ui.SetInputEventsEnabled(enabled)
+}
+
+// InputEvents returns the input events since the previous update in the order they happened.
+//
+// Unlike the functions like IsKeyPressed, InputEvents doesn't lose inputs shorter than a frame:
+// e.g., a key pressed and released in one frame is reported as InputEventTypeKeyDown and InputEventTypeKeyUp.
+// Repeated key events while a key is held down are not reported.
+//
+// InputEvents returns nil unless the events are enabled by SetInputEventsEnabled.
+//
+// On browsers, Time is the timestamp of the DOM event.
+// On desktops, GLFW doesn't tell the time of an event, and Time is the time when the event is processed,
+// which is around the beginning of the frame.
+// On mobiles, only the touch events are reported, and Time is the time when the touches are updated.
+//
+// Gamepads are not reported as events.
+//
+// This function is concurrent-safe.
+func InputEvents() []InputEvent {
+	es := ui.CurrentInput().InputEvents()
+	if len(es) == 0 {
+		return nil
+	}
+	events := make([]InputEvent, len(es))
+	for i, e := range es {
+		events[i] = InputEvent{
+			Type:        InputEventType(e.Type),
+			Time:        e.Time,
+			Key:         Key(e.Key),
+			Char:        e.Char,
+			MouseButton: MouseButton(e.MouseButton),
+			TouchID:     e.TouchID,
+			X:           e.X,
+			Y:           e.Y,
+			WheelX:      e.WheelX,
+			WheelY:      e.WheelY,
+		}
+	}
+	return events
+}
@@ -0,0 +1,309 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inputrecord provides functions to record the input states and to play them back.
+//
+// The input states are recorded every tick, i.e., every call of the update function passed to ebiten.Run.
+// While a record is played back, the input functions like ebiten.IsKeyPressed, ebiten.CursorPosition
+// and the functions of inpututil return the recorded states instead of the devices' states,
+// and one recorded tick is consumed by one tick regardless of the time.
+// As long as the game's update function depends only on the input states and the number of ticks,
+// e.g., the game doesn't use the wall-clock time or unseeded random numbers,
+// the playback reproduces the recorded simulation exactly.
+//
+// A record consists of a header line and one line for each tick in JSON.
+// The header has the version of the format, and a record of an unsupported version is rejected.
+//
+// The recorded states are the keys, the mouse buttons, the cursor position, the wheel,
+// the gamepads, the touches and the characters of ebiten.InputChars.
+// The dropped files and the input events are not recorded.
+//
+// Note: This package is experimental and API might be changed.
+package inputrecord
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/internal/hooks"
+	"github.com/dave/ebiten/internal/sync"
+	"github.com/dave/ebiten/internal/ui"
+)
+
+const (
+	formatName = "ebiten-input-record"
+
+	// Version is the version of the record format that this package writes.
+	Version = 1
+)
+
+type header struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+}
+
+type tick struct {
+	Keys         []ebiten.Key `json:"keys,omitempty"`
+	MouseButtons []int        `json:"mouseButtons,omitempty"`
+	CursorX      int          `json:"cursorX"`
+	CursorY      int          `json:"cursorY"`
+	WheelX       float64      `json:"wheelX,omitempty"`
+	WheelY       float64      `json:"wheelY,omitempty"`
+	Chars        string       `json:"chars,omitempty"`
+	Gamepads     []gamepad    `json:"gamepads,omitempty"`
+	Touches      []touch      `json:"touches,omitempty"`
+}
+
+type gamepad struct {
+	ID            int       `json:"id"`
+	SDLID         string    `json:"sdlID,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	Standard      bool      `json:"standard,omitempty"`
+	Axes          []float64 `json:"axes,omitempty"`
+	ButtonPressed []bool    `json:"buttonPressed,omitempty"`
+	ButtonValues  []float64 `json:"buttonValues,omitempty"`
+	Hats          []int     `json:"hats,omitempty"`
+}
+
+type touch struct {
+	ID int `json:"id"`
+	X  int `json:"x"`
+	Y  int `json:"y"`
+}
+
+func stateToTick(s *ui.InputState) *tick {
+	t := &tick{
+		CursorX: s.CursorX,
+		CursorY: s.CursorY,
+		WheelX:  s.WheelX,
+		WheelY:  s.WheelY,
+		Chars:   string(s.Chars),
+	}
+	for _, k := range s.Keys {
+		t.Keys = append(t.Keys, ebiten.Key(k))
+	}
+	for _, b := range s.MouseButtons {
+		t.MouseButtons = append(t.MouseButtons, int(b))
+	}
+	for _, g := range s.Gamepads {
+		t.Gamepads = append(t.Gamepads, gamepad{
+			ID:            g.ID,
+			SDLID:         g.SDLID,
+			Name:          g.Name,
+			Standard:      g.Standard,
+			Axes:          g.Axes,
+			ButtonPressed: g.ButtonPressed,
+			ButtonValues:  g.ButtonValues,
+			Hats:          g.Hats,
+		})
+	}
+	for _, tt := range s.Touches {
+		t.Touches = append(t.Touches, touch{ID: tt.ID, X: tt.X, Y: tt.Y})
+	}
+	return t
+}
+
+func tickToState(t *tick) *ui.InputState {
+	s := &ui.InputState{
+		CursorX: t.CursorX,
+		CursorY: t.CursorY,
+		WheelX:  t.WheelX,
+		WheelY:  t.WheelY,
+		Chars:   []rune(t.Chars),
+	}
+	for _, k := range t.Keys {
+		s.Keys = append(s.Keys, ui.Key(k))
+	}
+	for _, b := range t.MouseButtons {
+		s.MouseButtons = append(s.MouseButtons, ui.MouseButton(b))
+	}
+	for _, g := range t.Gamepads {
+		s.Gamepads = append(s.Gamepads, ui.GamepadState{
+			ID:            g.ID,
+			SDLID:         g.SDLID,
+			Name:          g.Name,
+			Standard:      g.Standard,
+			Axes:          g.Axes,
+			ButtonPressed: g.ButtonPressed,
+			ButtonValues:  g.ButtonValues,
+			Hats:          g.Hats,
+		})
+	}
+	for _, tt := range t.Touches {
+		s.Touches = append(s.Touches, ui.TouchState{ID: tt.ID, X: tt.X, Y: tt.Y})
+	}
+	return s
+}
+
+type recorder struct {
+	// enc is not nil while recording.
+	enc *json.Encoder
+
+	// dec is not nil while playing.
+	dec *json.Decoder
+
+	m sync.Mutex
+}
+
+var theRecorder = &recorder{}
+
+func init() {
+	hooks.AppendHookOnAfterUpdate(func() error {
+		return theRecorder.afterUpdate()
+	})
+}
+
+func (r *recorder) afterUpdate() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.enc != nil {
+		if err := r.enc.Encode(stateToTick(ui.CurrentInput().InputState())); err != nil {
+			r.enc = nil
+			return err
+		}
+	}
+	if r.dec != nil {
+		if err := r.next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// next sets the next tick's states. If the record ends, the playback stops.
+// next must be called with the lock.
+func (r *recorder) next() error {
+	t := &tick{}
+	if err := r.dec.Decode(t); err != nil {
+		r.dec = nil
+		ui.CurrentInput().SetInputStateOverride(nil)
+		if err == io.EOF {
+			return nil
+		}
+		return fmt.Errorf("inputrecord: invalid record: %v", err)
+	}
+	ui.CurrentInput().SetInputStateOverride(tickToState(t))
+	return nil
+}
+
+// StartRecording starts recording the input states to w.
+//
+// The states are written every tick after the update function is called.
+// Call StopRecording to stop recording.
+//
+// StartRecording returns an error when recording or playing is already in progress.
+//
+// This function is concurrent-safe.
+func StartRecording(w io.Writer) error {
+	r := theRecorder
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.enc != nil {
+		return errors.New("inputrecord: recording is already in progress")
+	}
+	if r.dec != nil {
+		return errors.New("inputrecord: playing is in progress")
+	}
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(&header{Format: formatName, Version: Version}); err != nil {
+		return err
+	}
+	r.enc = enc
+	return nil
+}
+
+// StopRecording stops recording.
+//
+// This function is concurrent-safe.
+func StopRecording() {
+	r := theRecorder
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.enc = nil
+}
+
+// IsRecording reports whether recording is in progress.
+//
+// This function is concurrent-safe.
+func IsRecording() bool {
+	r := theRecorder
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.enc != nil
+}
+
+// StartPlayback starts playing back the record read from rd.
+//
+// The first recorded tick is used from the next tick.
+// The devices' states are ignored until the record ends or StopPlayback is called.
+//
+// StartPlayback returns an error when the record is invalid, when the record's version is not supported,
+// or when recording or playing is already in progress.
+// If the record turns out to be invalid in the middle, the error is returned from ebiten.Run.
+//
+// This function is concurrent-safe.
+func StartPlayback(rd io.Reader) error {
+	r := theRecorder
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.enc != nil {
+		return errors.New("inputrecord: recording is in progress")
+	}
+	if r.dec != nil {
+		return errors.New("inputrecord: playing is already in progress")
+	}
+	dec := json.NewDecoder(rd)
+	h := &header{}
+	if err := dec.Decode(h); err != nil {
+		return fmt.Errorf("inputrecord: invalid header: %v", err)
+	}
+	if h.Format != formatName {
+		return fmt.Errorf("inputrecord: unexpected format: %q", h.Format)
+	}
+	if h.Version < 1 || Version < h.Version {
+		return fmt.Errorf("inputrecord: unsupported version: %d", h.Version)
+	}
+	r.dec = dec
+	return r.next()
+}
+
+// StopPlayback stops playing back, and the devices' states are used again.
+//
+// This function is concurrent-safe.
+func StopPlayback() {
+	r := theRecorder
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.dec == nil {
+		return
+	}
+	r.dec = nil
+	ui.CurrentInput().SetInputStateOverride(nil)
+}
+
+// IsPlaying reports whether playing back is in progress.
+//
+// This function is concurrent-safe.
+func IsPlaying() bool {
+	r := theRecorder
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.dec != nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inputrecord_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dave/ebiten"
+	. "github.com/dave/ebiten/inputrecord"
+)
+
+func TestPlayback(t *testing.T) {
+	const record = `{"format":"ebiten-input-record","version":1}
+{"keys":["A","LeftShift"],"mouseButtons":[0],"cursorX":10,"cursorY":20,"chars":"a","gamepads":[{"id":1,"axes":[0.5],"buttonPressed":[false,true]}]}
+`
+	if err := StartPlayback(strings.NewReader(record)); err != nil {
+		t.Fatal(err)
+	}
+	defer StopPlayback()
+
+	if !IsPlaying() {
+		t.Errorf("IsPlaying(): got: false, want: true")
+	}
+	for _, k := range []ebiten.Key{ebiten.KeyA, ebiten.KeyLeftShift, ebiten.KeyShift} {
+		if !ebiten.IsKeyPressed(k) {
+			t.Errorf("IsKeyPressed(%s): got: false, want: true", k)
+		}
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyB) {
+		t.Errorf("IsKeyPressed(KeyB): got: true, want: false")
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		t.Errorf("IsMouseButtonPressed(MouseButtonLeft): got: false, want: true")
+	}
+	if x, y := ebiten.CursorPosition(); x != 10 || y != 20 {
+		t.Errorf("CursorPosition(): got: (%d, %d), want: (10, 20)", x, y)
+	}
+	if got := string(ebiten.InputChars()); got != "a" {
+		t.Errorf("InputChars(): got: %q, want: %q", got, "a")
+	}
+	if got := ebiten.GamepadIDs(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("GamepadIDs(): got: %v, want: [1]", got)
+	}
+	if got := ebiten.GamepadAxis(1, 0); got != 0.5 {
+		t.Errorf("GamepadAxis(1, 0): got: %f, want: 0.5", got)
+	}
+	if !ebiten.IsGamepadButtonPressed(1, ebiten.GamepadButton1) {
+		t.Errorf("IsGamepadButtonPressed(1, GamepadButton1): got: false, want: true")
+	}
+
+	StopPlayback()
+	if IsPlaying() {
+		t.Errorf("IsPlaying(): got: true, want: false")
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyA) {
+		t.Errorf("IsKeyPressed(KeyA) after StopPlayback: got: true, want: false")
+	}
+}
+
+func TestPlaybackError(t *testing.T) {
+	for _, record := range []string{
+		``,
+		`{"format":"foo","version":1}`,
+		`{"format":"ebiten-input-record","version":100}`,
+		"{\"format\":\"ebiten-input-record\",\"version\":1}\n{\"keys\":[\"NoSuchKey\"]}",
+	} {
+		if err := StartPlayback(strings.NewReader(record)); err == nil {
+			StopPlayback()
+			t.Errorf("StartPlayback(%q) must return an error", record)
+		}
+		if IsPlaying() {
+			t.Errorf("IsPlaying() after an error: got: true, want: false")
+		}
+	}
+}
+
+func TestRecordingHeader(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := StartRecording(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := StartPlayback(strings.NewReader("")); err == nil {
+		t.Errorf("StartPlayback while recording must return an error")
+	}
+	StopRecording()
+	if err := StartPlayback(buf); err != nil {
+		t.Errorf("StartPlayback with a recorded header: %v", err)
+	}
+	StopPlayback()
+}
@@ -0,0 +1,343 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inputtest provides functions to inject virtual input states for automated tests.
+//
+// Once a state is injected, the input functions like ebiten.IsKeyPressed and the functions of inpututil
+// use the injected states instead of the devices' states until Reset is called.
+// The injected states are reflected to inpututil at the next tick, as the devices' states are.
+//
+// The states of keys, buttons, the cursor, gamepads and touches persist until they are changed.
+// The wheel offsets and the input characters are valid only in the next tick.
+//
+// Update runs one tick without a window, so that the game's update function can be tested headlessly.
+// For example, after PressKey(ebiten.KeySpace), inpututil.IsKeyJustPressed(ebiten.KeySpace) returns true
+// in the function passed to the next Update.
+//
+// This package can't be used with the playback of the inputrecord package at the same time.
+//
+// Note: This package is experimental and API might be changed.
+package inputtest
+
+import (
+	"sort"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/internal/hooks"
+	"github.com/dave/ebiten/internal/sync"
+	"github.com/dave/ebiten/internal/ui"
+)
+
+type gamepadState struct {
+	axes    []float64
+	buttons []bool
+}
+
+type touchState struct {
+	x int
+	y int
+}
+
+type injector struct {
+	// active is true while the states are injected.
+	active bool
+
+	keys         map[ebiten.Key]struct{}
+	mouseButtons map[ebiten.MouseButton]struct{}
+	cursorX      int
+	cursorY      int
+	wheelX       float64
+	wheelY       float64
+	chars        []rune
+	gamepads     map[int]*gamepadState
+	touches      map[int]touchState
+
+	m sync.Mutex
+}
+
+var theInjector = &injector{}
+
+// clear removes all the injected states. clear must be called with the lock.
+func (i *injector) clear() {
+	i.active = false
+	i.keys = map[ebiten.Key]struct{}{}
+	i.mouseButtons = map[ebiten.MouseButton]struct{}{}
+	i.cursorX, i.cursorY = 0, 0
+	i.wheelX, i.wheelY = 0, 0
+	i.chars = nil
+	i.gamepads = map[int]*gamepadState{}
+	i.touches = map[int]touchState{}
+}
+
+func init() {
+	theInjector.clear()
+	hooks.AppendHookOnAfterUpdate(func() error {
+		theInjector.afterUpdate()
+		return nil
+	})
+}
+
+func (i *injector) afterUpdate() {
+	i.m.Lock()
+	defer i.m.Unlock()
+	if !i.active {
+		return
+	}
+	i.wheelX, i.wheelY = 0, 0
+	i.chars = nil
+	i.apply()
+}
+
+// update calls f with the lock and applies the injected states.
+func (i *injector) update(f func()) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	f()
+	i.active = true
+	i.apply()
+}
+
+// apply must be called with the lock.
+func (i *injector) apply() {
+	s := &ui.InputState{
+		CursorX: i.cursorX,
+		CursorY: i.cursorY,
+		WheelX:  i.wheelX,
+		WheelY:  i.wheelY,
+		Chars:   append([]rune{}, i.chars...),
+	}
+	for k := range i.keys {
+		s.Keys = append(s.Keys, ui.Key(k))
+	}
+	for b := range i.mouseButtons {
+		s.MouseButtons = append(s.MouseButtons, ui.MouseButton(b))
+	}
+	ids := []int{}
+	for id := range i.gamepads {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		g := i.gamepads[id]
+		values := make([]float64, len(g.buttons))
+		for b, p := range g.buttons {
+			if p {
+				values[b] = 1
+			}
+		}
+		s.Gamepads = append(s.Gamepads, ui.GamepadState{
+			ID:            id,
+			Axes:          append([]float64{}, g.axes...),
+			ButtonPressed: append([]bool{}, g.buttons...),
+			ButtonValues:  values,
+		})
+	}
+	ids = ids[:0]
+	for id := range i.touches {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		t := i.touches[id]
+		s.Touches = append(s.Touches, ui.TouchState{ID: id, X: t.x, Y: t.y})
+	}
+	ui.CurrentInput().SetInputStateOverride(s)
+}
+
+// gamepad returns the gamepad state for id, connecting the gamepad if needed.
+// gamepad must be called with the lock.
+func (i *injector) gamepad(id int) *gamepadState {
+	g, ok := i.gamepads[id]
+	if !ok {
+		g = &gamepadState{}
+		i.gamepads[id] = g
+	}
+	return g
+}
+
+// PressKey makes the key (key) pressed.
+//
+// This function is concurrent-safe.
+func PressKey(key ebiten.Key) {
+	theInjector.update(func() {
+		theInjector.keys[key] = struct{}{}
+	})
+}
+
+// ReleaseKey makes the key (key) released.
+//
+// This function is concurrent-safe.
+func ReleaseKey(key ebiten.Key) {
+	theInjector.update(func() {
+		delete(theInjector.keys, key)
+	})
+}
+
+// PressMouseButton makes the mouse button (button) pressed.
+//
+// This function is concurrent-safe.
+func PressMouseButton(button ebiten.MouseButton) {
+	theInjector.update(func() {
+		theInjector.mouseButtons[button] = struct{}{}
+	})
+}
+
+// ReleaseMouseButton makes the mouse button (button) released.
+//
+// This function is concurrent-safe.
+func ReleaseMouseButton(button ebiten.MouseButton) {
+	theInjector.update(func() {
+		delete(theInjector.mouseButtons, button)
+	})
+}
+
+// SetCursorPosition sets the cursor position in logical screen coordinates.
+//
+// This function is concurrent-safe.
+func SetCursorPosition(x, y int) {
+	theInjector.update(func() {
+		theInjector.cursorX = x
+		theInjector.cursorY = y
+	})
+}
+
+// Scroll adds the wheel offsets for the next tick.
+// See ebiten.Wheel for the units.
+//
+// This function is concurrent-safe.
+func Scroll(xoff, yoff float64) {
+	theInjector.update(func() {
+		theInjector.wheelX += xoff
+		theInjector.wheelY += yoff
+	})
+}
+
+// AppendInputChars adds the characters returned by ebiten.InputChars in the next tick.
+//
+// This function is concurrent-safe.
+func AppendInputChars(chars ...rune) {
+	theInjector.update(func() {
+		theInjector.chars = append(theInjector.chars, chars...)
+	})
+}
+
+// ConnectGamepad connects a gamepad (id) without any axes and buttons.
+//
+// SetGamepadAxis and PressGamepadButton also connect the gamepad if needed.
+//
+// This function is concurrent-safe.
+func ConnectGamepad(id int) {
+	theInjector.update(func() {
+		theInjector.gamepad(id)
+	})
+}
+
+// DisconnectGamepad disconnects the gamepad (id).
+//
+// This function is concurrent-safe.
+func DisconnectGamepad(id int) {
+	theInjector.update(func() {
+		delete(theInjector.gamepads, id)
+	})
+}
+
+// SetGamepadAxis sets the value of the axis (axis) of the gamepad (id).
+//
+// The number of the gamepad's axes is extended to include axis if needed.
+//
+// This function is concurrent-safe.
+func SetGamepadAxis(id int, axis int, value float64) {
+	theInjector.update(func() {
+		g := theInjector.gamepad(id)
+		for len(g.axes) <= axis {
+			g.axes = append(g.axes, 0)
+		}
+		g.axes[axis] = value
+	})
+}
+
+// PressGamepadButton makes the button (button) of the gamepad (id) pressed.
+//
+// The number of the gamepad's buttons is extended to include button if needed.
+//
+// This function is concurrent-safe.
+func PressGamepadButton(id int, button ebiten.GamepadButton) {
+	theInjector.update(func() {
+		g := theInjector.gamepad(id)
+		for len(g.buttons) <= int(button) {
+			g.buttons = append(g.buttons, false)
+		}
+		g.buttons[button] = true
+	})
+}
+
+// ReleaseGamepadButton makes the button (button) of the gamepad (id) released.
+//
+// This function is concurrent-safe.
+func ReleaseGamepadButton(id int, button ebiten.GamepadButton) {
+	theInjector.update(func() {
+		g := theInjector.gamepad(id)
+		if int(button) < len(g.buttons) {
+			g.buttons[button] = false
+		}
+	})
+}
+
+// SetTouch starts the touch (id) at (x, y), or moves the touch if it already exists.
+//
+// This function is concurrent-safe.
+func SetTouch(id int, x, y int) {
+	theInjector.update(func() {
+		theInjector.touches[id] = touchState{x: x, y: y}
+	})
+}
+
+// ReleaseTouch ends the touch (id).
+//
+// This function is concurrent-safe.
+func ReleaseTouch(id int) {
+	theInjector.update(func() {
+		delete(theInjector.touches, id)
+	})
+}
+
+// Reset removes all the injected states, and the devices' states are used again.
+//
+// This function is concurrent-safe.
+func Reset() {
+	i := theInjector
+	i.m.Lock()
+	defer i.m.Unlock()
+	if !i.active {
+		return
+	}
+	i.clear()
+	ui.CurrentInput().SetInputStateOverride(nil)
+}
+
+// Update runs one tick without a window.
+//
+// Update updates the states of inpututil, calls f, and then ends the tick:
+// the wheel offsets and the input characters injected for the tick are removed.
+//
+// Update must not be called while the game is running by ebiten.Run.
+func Update(f func() error) error {
+	if err := hooks.Run(); err != nil {
+		return err
+	}
+	if err := f(); err != nil {
+		return err
+	}
+	return hooks.RunAfterUpdate()
+}
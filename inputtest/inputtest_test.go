@@ -0,0 +1,143 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inputtest_test
+
+import (
+	"testing"
+
+	"github.com/dave/ebiten"
+	. "github.com/dave/ebiten/inputtest"
+	"github.com/dave/ebiten/inpututil"
+)
+
+func TestKey(t *testing.T) {
+	defer Reset()
+
+	PressKey(ebiten.KeyA)
+	if err := Update(func() error {
+		if !ebiten.IsKeyPressed(ebiten.KeyA) {
+			t.Errorf("IsKeyPressed(KeyA): got: false, want: true")
+		}
+		if !inpututil.IsKeyJustPressed(ebiten.KeyA) {
+			t.Errorf("IsKeyJustPressed(KeyA): got: false, want: true")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Update(func() error {
+		if inpututil.IsKeyJustPressed(ebiten.KeyA) {
+			t.Errorf("IsKeyJustPressed(KeyA) at the second tick: got: true, want: false")
+		}
+		if got := inpututil.KeyPressDuration(ebiten.KeyA); got != 2 {
+			t.Errorf("KeyPressDuration(KeyA): got: %d, want: 2", got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ReleaseKey(ebiten.KeyA)
+	if err := Update(func() error {
+		if ebiten.IsKeyPressed(ebiten.KeyA) {
+			t.Errorf("IsKeyPressed(KeyA) after ReleaseKey: got: true, want: false")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWheelAndChars(t *testing.T) {
+	defer Reset()
+
+	Scroll(0, 1)
+	AppendInputChars('a', 'b')
+	if err := Update(func() error {
+		if x, y := ebiten.Wheel(); x != 0 || y != 1 {
+			t.Errorf("Wheel(): got: (%f, %f), want: (0, 1)", x, y)
+		}
+		if got := string(ebiten.InputChars()); got != "ab" {
+			t.Errorf("InputChars(): got: %q, want: %q", got, "ab")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Update(func() error {
+		if x, y := ebiten.Wheel(); x != 0 || y != 0 {
+			t.Errorf("Wheel() at the second tick: got: (%f, %f), want: (0, 0)", x, y)
+		}
+		if got := ebiten.InputChars(); len(got) != 0 {
+			t.Errorf("InputChars() at the second tick: got: %q, want: empty", string(got))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGamepadAndTouch(t *testing.T) {
+	defer Reset()
+
+	SetGamepadAxis(0, 1, -1)
+	PressGamepadButton(0, ebiten.GamepadButton2)
+	SetTouch(5, 10, 20)
+	SetCursorPosition(30, 40)
+	PressMouseButton(ebiten.MouseButtonRight)
+	if err := Update(func() error {
+		if got := ebiten.GamepadAxisNum(0); got != 2 {
+			t.Errorf("GamepadAxisNum(0): got: %d, want: 2", got)
+		}
+		if got := ebiten.GamepadAxis(0, 1); got != -1 {
+			t.Errorf("GamepadAxis(0, 1): got: %f, want: -1", got)
+		}
+		if !inpututil.IsGamepadButtonJustPressed(0, ebiten.GamepadButton2) {
+			t.Errorf("IsGamepadButtonJustPressed(0, GamepadButton2): got: false, want: true")
+		}
+		if !inpututil.IsJustTouched(5) {
+			t.Errorf("IsJustTouched(5): got: false, want: true")
+		}
+		ts := ebiten.Touches()
+		if len(ts) != 1 {
+			t.Fatalf("len(Touches()): got: %d, want: 1", len(ts))
+		}
+		if x, y := ts[0].Position(); ts[0].ID() != 5 || x != 10 || y != 20 {
+			t.Errorf("Touches()[0]: got: %d (%d, %d), want: 5 (10, 20)", ts[0].ID(), x, y)
+		}
+		if x, y := ebiten.CursorPosition(); x != 30 || y != 40 {
+			t.Errorf("CursorPosition(): got: (%d, %d), want: (30, 40)", x, y)
+		}
+		if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+			t.Errorf("IsMouseButtonJustPressed(MouseButtonRight): got: false, want: true")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	DisconnectGamepad(0)
+	ReleaseTouch(5)
+	if err := Update(func() error {
+		if !inpututil.IsGamepadJustDisconnected(0) {
+			t.Errorf("IsGamepadJustDisconnected(0): got: false, want: true")
+		}
+		if got := len(ebiten.Touches()); got != 0 {
+			t.Errorf("len(Touches()): got: %d, want: 0", got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
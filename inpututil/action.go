@@ -0,0 +1,418 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/dave/ebiten"
+)
+
+// A BindingType represents the type of the input of a Binding.
+type BindingType int
+
+// BindingTypes
+const (
+	BindingTypeKey BindingType = iota
+	BindingTypeMouseButton
+	BindingTypeGamepadButton
+	BindingTypeStandardGamepadButton
+	BindingTypeGamepadAxis
+	BindingTypeStandardGamepadAxis
+)
+
+var bindingTypeNames = map[BindingType]string{
+	BindingTypeKey:                   "key",
+	BindingTypeMouseButton:           "mouseButton",
+	BindingTypeGamepadButton:         "gamepadButton",
+	BindingTypeStandardGamepadButton: "standardGamepadButton",
+	BindingTypeGamepadAxis:           "gamepadAxis",
+	BindingTypeStandardGamepadAxis:   "standardGamepadAxis",
+}
+
+// A Binding represents an input bound to an action.
+//
+// Only the field for Type is used among Key, MouseButton, GamepadButton, StandardGamepadButton,
+// GamepadAxis and StandardGamepadAxis.
+// The gamepad inputs are satisfied by any connected gamepad.
+type Binding struct {
+	Type BindingType
+
+	Key                   ebiten.Key
+	MouseButton           ebiten.MouseButton
+	GamepadButton         ebiten.GamepadButton
+	StandardGamepadButton ebiten.StandardGamepadButton
+	GamepadAxis           int
+	StandardGamepadAxis   ebiten.StandardGamepadAxis
+
+	// Direction is the direction of the axis for the axis types.
+	// If Direction is 1 or -1, only the positive or negative half of the axis is used,
+	// and the action value is in [0, 1].
+	// If Direction is 0, the whole axis is used, and the action value is in [-1, 1].
+	Direction int
+
+	// Deadzone is the threshold of the absolute value under which the input is treated as 0.
+	// Values beyond the threshold are rescaled so that the action value still reaches 1.
+	//
+	// Deadzone is used for the axis types and BindingTypeStandardGamepadButton, which might be analog.
+	Deadzone float64
+}
+
+// KeyBinding returns a Binding for the key.
+func KeyBinding(key ebiten.Key) Binding {
+	return Binding{Type: BindingTypeKey, Key: key}
+}
+
+// MouseButtonBinding returns a Binding for the mouse button.
+func MouseButtonBinding(button ebiten.MouseButton) Binding {
+	return Binding{Type: BindingTypeMouseButton, MouseButton: button}
+}
+
+// GamepadButtonBinding returns a Binding for the gamepad button.
+func GamepadButtonBinding(button ebiten.GamepadButton) Binding {
+	return Binding{Type: BindingTypeGamepadButton, GamepadButton: button}
+}
+
+// StandardGamepadButtonBinding returns a Binding for the gamepad button in the standard layout.
+func StandardGamepadButtonBinding(button ebiten.StandardGamepadButton) Binding {
+	return Binding{Type: BindingTypeStandardGamepadButton, StandardGamepadButton: button}
+}
+
+// GamepadAxisBinding returns a Binding for the gamepad axis with the direction and the deadzone.
+func GamepadAxisBinding(axis int, direction int, deadzone float64) Binding {
+	return Binding{Type: BindingTypeGamepadAxis, GamepadAxis: axis, Direction: direction, Deadzone: deadzone}
+}
+
+// StandardGamepadAxisBinding returns a Binding for the gamepad axis in the standard layout with the direction and the deadzone.
+func StandardGamepadAxisBinding(axis ebiten.StandardGamepadAxis, direction int, deadzone float64) Binding {
+	return Binding{Type: BindingTypeStandardGamepadAxis, StandardGamepadAxis: axis, Direction: direction, Deadzone: deadzone}
+}
+
+// applyDeadzone returns the value after the direction and the deadzone are applied.
+func (b *Binding) applyDeadzone(v float64) float64 {
+	switch {
+	case b.Direction > 0:
+		v = math.Max(v, 0)
+	case b.Direction < 0:
+		v = math.Max(-v, 0)
+	}
+	a := math.Abs(v)
+	if a <= b.Deadzone {
+		return 0
+	}
+	if b.Deadzone > 0 && b.Deadzone < 1 {
+		a = (a - b.Deadzone) / (1 - b.Deadzone)
+	}
+	a = math.Min(a, 1)
+	if v < 0 {
+		return -a
+	}
+	return a
+}
+
+func boolToValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// value returns the current value of the binding.
+func (b *Binding) value() float64 {
+	switch b.Type {
+	case BindingTypeKey:
+		return boolToValue(ebiten.IsKeyPressed(b.Key))
+	case BindingTypeMouseButton:
+		return boolToValue(ebiten.IsMouseButtonPressed(b.MouseButton))
+	}
+
+	// Use the value of the gamepad which has the largest absolute value.
+	v := 0.0
+	for _, id := range ebiten.GamepadIDs() {
+		vv := 0.0
+		switch b.Type {
+		case BindingTypeGamepadButton:
+			vv = boolToValue(ebiten.IsGamepadButtonPressed(id, b.GamepadButton))
+		case BindingTypeStandardGamepadButton:
+			vv = b.applyDeadzone(ebiten.StandardGamepadButtonValue(id, b.StandardGamepadButton))
+		case BindingTypeGamepadAxis:
+			vv = b.applyDeadzone(ebiten.GamepadAxis(id, b.GamepadAxis))
+		case BindingTypeStandardGamepadAxis:
+			vv = b.applyDeadzone(ebiten.StandardGamepadAxisValue(id, b.StandardGamepadAxis))
+		}
+		if math.Abs(v) < math.Abs(vv) {
+			v = vv
+		}
+	}
+	return v
+}
+
+type jsonBinding struct {
+	Type      string      `json:"type"`
+	Key       *ebiten.Key `json:"key,omitempty"`
+	Button    *int        `json:"button,omitempty"`
+	Axis      *int        `json:"axis,omitempty"`
+	Direction int         `json:"direction,omitempty"`
+	Deadzone  float64     `json:"deadzone,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Binding) MarshalJSON() ([]byte, error) {
+	name, ok := bindingTypeNames[b.Type]
+	if !ok {
+		return nil, fmt.Errorf("inpututil: invalid binding type: %d", b.Type)
+	}
+	j := &jsonBinding{
+		Type: name,
+	}
+	intp := func(v int) *int {
+		return &v
+	}
+	switch b.Type {
+	case BindingTypeKey:
+		k := b.Key
+		j.Key = &k
+	case BindingTypeMouseButton:
+		j.Button = intp(int(b.MouseButton))
+	case BindingTypeGamepadButton:
+		j.Button = intp(int(b.GamepadButton))
+	case BindingTypeStandardGamepadButton:
+		j.Button = intp(int(b.StandardGamepadButton))
+		j.Deadzone = b.Deadzone
+	case BindingTypeGamepadAxis:
+		j.Axis = intp(b.GamepadAxis)
+		j.Direction = b.Direction
+		j.Deadzone = b.Deadzone
+	case BindingTypeStandardGamepadAxis:
+		j.Axis = intp(int(b.StandardGamepadAxis))
+		j.Direction = b.Direction
+		j.Deadzone = b.Deadzone
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Binding) UnmarshalJSON(data []byte) error {
+	j := &jsonBinding{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return err
+	}
+	t := BindingType(-1)
+	for bt, name := range bindingTypeNames {
+		if name == j.Type {
+			t = bt
+			break
+		}
+	}
+	if t == -1 {
+		return fmt.Errorf("inpututil: invalid binding type: %q", j.Type)
+	}
+
+	nb := Binding{
+		Type: t,
+	}
+	switch t {
+	case BindingTypeKey:
+		if j.Key == nil {
+			return fmt.Errorf("inpututil: key is missing for the binding type %q", j.Type)
+		}
+		nb.Key = *j.Key
+	case BindingTypeMouseButton, BindingTypeGamepadButton, BindingTypeStandardGamepadButton:
+		if j.Button == nil {
+			return fmt.Errorf("inpututil: button is missing for the binding type %q", j.Type)
+		}
+		switch t {
+		case BindingTypeMouseButton:
+			nb.MouseButton = ebiten.MouseButton(*j.Button)
+		case BindingTypeGamepadButton:
+			nb.GamepadButton = ebiten.GamepadButton(*j.Button)
+		case BindingTypeStandardGamepadButton:
+			nb.StandardGamepadButton = ebiten.StandardGamepadButton(*j.Button)
+			nb.Deadzone = j.Deadzone
+		}
+	case BindingTypeGamepadAxis, BindingTypeStandardGamepadAxis:
+		if j.Axis == nil {
+			return fmt.Errorf("inpututil: axis is missing for the binding type %q", j.Type)
+		}
+		if t == BindingTypeGamepadAxis {
+			nb.GamepadAxis = *j.Axis
+		} else {
+			nb.StandardGamepadAxis = ebiten.StandardGamepadAxis(*j.Axis)
+		}
+		nb.Direction = j.Direction
+		nb.Deadzone = j.Deadzone
+	}
+	*b = nb
+	return nil
+}
+
+type actionState struct {
+	bindings []Binding
+	value    float64
+	duration int
+}
+
+// updateActions must be called with the lock.
+func (i *inputState) updateActions() {
+	for _, a := range i.actions {
+		a.value = 0
+		for _, b := range a.bindings {
+			if v := b.value(); math.Abs(a.value) < math.Abs(v) {
+				a.value = v
+			}
+		}
+		if a.value != 0 {
+			a.duration++
+		} else {
+			a.duration = 0
+		}
+	}
+}
+
+// BindAction binds the inputs (bindings) to the action (action).
+// The existing bindings of the action are replaced.
+//
+// The action's states are updated from the next frame.
+//
+// This function is concurrent-safe.
+func BindAction(action string, bindings ...Binding) {
+	theInputState.m.Lock()
+	defer theInputState.m.Unlock()
+	if a, ok := theInputState.actions[action]; ok {
+		a.bindings = append([]Binding{}, bindings...)
+		return
+	}
+	theInputState.actions[action] = &actionState{
+		bindings: append([]Binding{}, bindings...),
+	}
+}
+
+// UnbindAction removes the action (action) and its bindings.
+//
+// This function is concurrent-safe.
+func UnbindAction(action string) {
+	theInputState.m.Lock()
+	defer theInputState.m.Unlock()
+	delete(theInputState.actions, action)
+}
+
+// ActionBindings returns the bindings of the action (action).
+//
+// This function is concurrent-safe.
+func ActionBindings(action string) []Binding {
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+	a, ok := theInputState.actions[action]
+	if !ok {
+		return nil
+	}
+	return append([]Binding{}, a.bindings...)
+}
+
+// ActionValue returns the value of the action (action) in the current frame.
+//
+// The value is the value of the binding which has the largest absolute value.
+// The value of a key or a button is 0 or 1.
+// The value of an axis is in [-1, 1] or [0, 1] depending on its direction.
+//
+// This function is concurrent-safe.
+func ActionValue(action string) float64 {
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+	a, ok := theInputState.actions[action]
+	if !ok {
+		return 0
+	}
+	return a.value
+}
+
+// IsActionPressed returns a boolean value indicating whether the action (action) is pressed,
+// i.e., the action's value is not 0 in the current frame.
+//
+// This function is concurrent-safe.
+func IsActionPressed(action string) bool {
+	return ActionPressDuration(action) > 0
+}
+
+// IsActionJustPressed returns a boolean value indicating
+// whether the action (action) is pressed just in the current frame.
+//
+// This function is concurrent-safe.
+func IsActionJustPressed(action string) bool {
+	return ActionPressDuration(action) == 1
+}
+
+// ActionPressDuration returns how long the action (action) is pressed in frames.
+//
+// This function is concurrent-safe.
+func ActionPressDuration(action string) int {
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+	a, ok := theInputState.actions[action]
+	if !ok {
+		return 0
+	}
+	return a.duration
+}
+
+// MarshalActionBindings returns the bindings of all the actions in JSON.
+//
+// The JSON is an object that maps action names to arrays of bindings like
+// {"jump":[{"type":"key","key":"Space"},{"type":"standardGamepadButton","button":0}]}.
+// Keys are represented by their names like "Space".
+//
+// This function is concurrent-safe.
+func MarshalActionBindings() ([]byte, error) {
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+	m := map[string][]Binding{}
+	for name, a := range theInputState.actions {
+		m[name] = a.bindings
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalActionBindings replaces the bindings of all the actions with the given JSON.
+// See MarshalActionBindings for the format.
+//
+// If data is invalid, UnmarshalActionBindings returns an error and the bindings are not changed.
+//
+// This function is concurrent-safe.
+func UnmarshalActionBindings(data []byte) error {
+	m := map[string][]Binding{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	theInputState.m.Lock()
+	defer theInputState.m.Unlock()
+	for name := range theInputState.actions {
+		if _, ok := m[name]; !ok {
+			delete(theInputState.actions, name)
+		}
+	}
+	for name, bs := range m {
+		if a, ok := theInputState.actions[name]; ok {
+			a.bindings = bs
+			continue
+		}
+		theInputState.actions[name] = &actionState{
+			bindings: bs,
+		}
+	}
+	return nil
+}
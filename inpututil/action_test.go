@@ -0,0 +1,115 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/inputtest"
+	. "github.com/dave/ebiten/inpututil"
+)
+
+func TestAction(t *testing.T) {
+	defer inputtest.Reset()
+	defer UnbindAction("jump")
+	defer UnbindAction("moveX")
+
+	BindAction("jump", KeyBinding(ebiten.KeySpace), GamepadButtonBinding(ebiten.GamepadButton0))
+	BindAction("moveX", GamepadAxisBinding(0, 0, 0.5))
+
+	inputtest.PressKey(ebiten.KeySpace)
+	inputtest.SetGamepadAxis(0, 0, -0.75)
+	if err := inputtest.Update(func() error {
+		if !IsActionJustPressed("jump") {
+			t.Errorf("IsActionJustPressed(jump): got: false, want: true")
+		}
+		if got := ActionValue("moveX"); got != -0.5 {
+			t.Errorf("ActionValue(moveX): got: %f, want: -0.5", got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Switching the bindings while pressing keeps the action pressed.
+	inputtest.PressGamepadButton(0, ebiten.GamepadButton0)
+	inputtest.ReleaseKey(ebiten.KeySpace)
+	inputtest.SetGamepadAxis(0, 0, 0.25)
+	if err := inputtest.Update(func() error {
+		if got := ActionPressDuration("jump"); got != 2 {
+			t.Errorf("ActionPressDuration(jump): got: %d, want: 2", got)
+		}
+		if got := ActionValue("moveX"); got != 0 {
+			t.Errorf("ActionValue(moveX) in the deadzone: got: %f, want: 0", got)
+		}
+		if IsActionPressed("moveX") {
+			t.Errorf("IsActionPressed(moveX) in the deadzone: got: true, want: false")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestActionBindingsJSON(t *testing.T) {
+	defer UnbindAction("jump")
+	defer UnbindAction("moveX")
+
+	jump := []Binding{
+		KeyBinding(ebiten.KeySpace),
+		StandardGamepadButtonBinding(ebiten.StandardGamepadButtonA),
+	}
+	moveX := []Binding{
+		StandardGamepadAxisBinding(ebiten.StandardGamepadAxisLeftStickHorizontal, -1, 0.2),
+	}
+	BindAction("jump", jump...)
+	BindAction("moveX", moveX...)
+
+	data, err := MarshalActionBindings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"jump":[{"type":"key","key":"Space"},{"type":"standardGamepadButton","button":0}],"moveX":[{"type":"standardGamepadAxis","axis":0,"direction":-1,"deadzone":0.2}]}`
+	if string(data) != want {
+		t.Errorf("MarshalActionBindings(): got: %s, want: %s", data, want)
+	}
+
+	UnbindAction("jump")
+	UnbindAction("moveX")
+	if err := UnmarshalActionBindings(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := ActionBindings("jump"); !reflect.DeepEqual(got, jump) {
+		t.Errorf("ActionBindings(jump): got: %v, want: %v", got, jump)
+	}
+	if got := ActionBindings("moveX"); !reflect.DeepEqual(got, moveX) {
+		t.Errorf("ActionBindings(moveX): got: %v, want: %v", got, moveX)
+	}
+
+	for _, data := range []string{
+		`{"jump":[{"type":"foo"}]}`,
+		`{"jump":[{"type":"key","key":"NoSuchKey"}]}`,
+		`{"jump":[{"type":"gamepadButton"}]}`,
+	} {
+		if err := UnmarshalActionBindings([]byte(data)); err == nil {
+			t.Errorf("UnmarshalActionBindings(%s) must return an error", data)
+		}
+	}
+	if got := ActionBindings("jump"); !reflect.DeepEqual(got, jump) {
+		t.Errorf("ActionBindings(jump) after errors: got: %v, want: %v", got, jump)
+	}
+}
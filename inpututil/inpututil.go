@@ -18,25 +18,50 @@
 package inpututil
 
 import (
+	"sort"
+
 	"github.com/dave/ebiten"
 	"github.com/dave/ebiten/internal/hooks"
 	"github.com/dave/ebiten/internal/sync"
 )
 
 type inputState struct {
-	keyStates           map[ebiten.Key]int
-	mouseButtonStates   map[ebiten.MouseButton]int
-	gamepadButtonStates map[int]map[ebiten.GamepadButton]int
-	touchStates         map[int]int
+	keyStates               map[ebiten.Key]int
+	prevKeyStates           map[ebiten.Key]int
+	mouseButtonStates       map[ebiten.MouseButton]int
+	prevMouseButtonStates   map[ebiten.MouseButton]int
+	gamepadButtonStates     map[int]map[ebiten.GamepadButton]int
+	prevGamepadButtonStates map[int]map[ebiten.GamepadButton]int
+	gamepadIDs              map[int]struct{}
+	prevGamepadIDs          map[int]struct{}
+	touchStates             map[int]int
+	prevTouchStates         map[int]int
+	touchPositions          map[int]position
+	prevTouchPositions      map[int]position
+	cursorX                 int
+	cursorY                 int
+	wheelX                  float64
+	wheelY                  float64
+	wheelState              int
+	actions                 map[string]*actionState
 
 	m sync.RWMutex
 }
 
 var theInputState = &inputState{
-	keyStates:           map[ebiten.Key]int{},
-	mouseButtonStates:   map[ebiten.MouseButton]int{},
-	gamepadButtonStates: map[int]map[ebiten.GamepadButton]int{},
-	touchStates:         map[int]int{},
+	keyStates:               map[ebiten.Key]int{},
+	prevKeyStates:           map[ebiten.Key]int{},
+	mouseButtonStates:       map[ebiten.MouseButton]int{},
+	prevMouseButtonStates:   map[ebiten.MouseButton]int{},
+	gamepadButtonStates:     map[int]map[ebiten.GamepadButton]int{},
+	prevGamepadButtonStates: map[int]map[ebiten.GamepadButton]int{},
+	gamepadIDs:              map[int]struct{}{},
+	prevGamepadIDs:          map[int]struct{}{},
+	touchStates:             map[int]int{},
+	prevTouchStates:         map[int]int{},
+	touchPositions:          map[int]position{},
+	prevTouchPositions:      map[int]position{},
+	actions:                 map[string]*actionState{},
 }
 
 func init() {
@@ -52,6 +77,7 @@ func (i *inputState) update() {
 
 	// Keyboard
 	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+		i.prevKeyStates[k] = i.keyStates[k]
 		if ebiten.IsKeyPressed(k) {
 			i.keyStates[k]++
 		} else {
@@ -65,6 +91,7 @@ func (i *inputState) update() {
 		ebiten.MouseButtonRight,
 		ebiten.MouseButtonMiddle,
 	} {
+		i.prevMouseButtonStates[b] = i.mouseButtonStates[b]
 		if ebiten.IsMouseButtonPressed(b) {
 			i.mouseButtonStates[b]++
 		} else {
@@ -72,47 +99,52 @@ func (i *inputState) update() {
 		}
 	}
 
+	// Cursor
+	i.cursorX, i.cursorY = ebiten.CursorPosition()
+
+	// Wheel
+	i.wheelX, i.wheelY = ebiten.Wheel()
+	if i.wheelX != 0 || i.wheelY != 0 {
+		i.wheelState++
+	} else {
+		i.wheelState = 0
+	}
+
 	// Gamepads
-	ids := map[int]struct{}{}
+	// The states of disconnected gamepads and released touches are dropped
+	// by building new maps from the previous ones.
+	i.prevGamepadIDs = i.gamepadIDs
+	i.gamepadIDs = map[int]struct{}{}
+	i.prevGamepadButtonStates = i.gamepadButtonStates
+	i.gamepadButtonStates = map[int]map[ebiten.GamepadButton]int{}
 	for _, id := range ebiten.GamepadIDs() {
-		ids[id] = struct{}{}
-		if _, ok := i.gamepadButtonStates[id]; !ok {
-			i.gamepadButtonStates[id] = map[ebiten.GamepadButton]int{}
-		}
+		i.gamepadIDs[id] = struct{}{}
+		prev := i.prevGamepadButtonStates[id]
+		states := map[ebiten.GamepadButton]int{}
 		n := ebiten.GamepadButtonNum(id)
 		for b := ebiten.GamepadButton(0); b < ebiten.GamepadButton(n); b++ {
 			if ebiten.IsGamepadButtonPressed(id, b) {
-				i.gamepadButtonStates[id][b]++
+				states[b] = prev[b] + 1
 			} else {
-				i.gamepadButtonStates[id][b] = 0
+				states[b] = 0
 			}
 		}
-	}
-	idsToDelete := []int{}
-	for id := range i.gamepadButtonStates {
-		if _, ok := ids[id]; !ok {
-			idsToDelete = append(idsToDelete, id)
-		}
-	}
-	for _, id := range idsToDelete {
-		delete(i.gamepadButtonStates, id)
+		i.gamepadButtonStates[id] = states
 	}
 
 	// Touches
-	ids = map[int]struct{}{}
+	i.prevTouchStates = i.touchStates
+	i.touchStates = map[int]int{}
+	i.prevTouchPositions = i.touchPositions
+	i.touchPositions = map[int]position{}
 	for _, t := range ebiten.Touches() {
-		ids[t.ID()] = struct{}{}
-		i.touchStates[t.ID()]++
-	}
-	idsToDelete = []int{}
-	for id := range i.touchStates {
-		if _, ok := ids[id]; !ok {
-			idsToDelete = append(idsToDelete, id)
-		}
-	}
-	for _, id := range idsToDelete {
-		delete(i.touchStates, id)
+		i.touchStates[t.ID()] = i.prevTouchStates[t.ID()] + 1
+		x, y := t.Position()
+		i.touchPositions[t.ID()] = position{x, y}
 	}
+
+	// Actions
+	i.updateActions()
 }
 
 // IsKeyJustPressed returns a boolean value indicating
@@ -121,6 +153,30 @@ func IsKeyJustPressed(key ebiten.Key) bool {
 	return KeyPressDuration(key) == 1
 }
 
+// IsKeyJustReleased returns a boolean value indicating
+// whether the given key is released just in the current frame.
+func IsKeyJustReleased(key ebiten.Key) bool {
+	theInputState.m.RLock()
+	r := theInputState.keyStates[key] == 0 && theInputState.prevKeyStates[key] > 0
+	theInputState.m.RUnlock()
+	return r
+}
+
+// AppendJustPressedKeys appends keys that are pressed just in the current frame to keys and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// The keys are appended in ascending order.
+func AppendJustPressedKeys(keys []ebiten.Key) []ebiten.Key {
+	theInputState.m.RLock()
+	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+		if theInputState.keyStates[k] == 1 {
+			keys = append(keys, k)
+		}
+	}
+	theInputState.m.RUnlock()
+	return keys
+}
+
 // KeyPressDuration returns how long the key is pressed in frames.
 func KeyPressDuration(key ebiten.Key) int {
 	theInputState.m.RLock()
@@ -135,6 +191,15 @@ func IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
 	return MouseButtonPressDuration(button) == 1
 }
 
+// IsMouseButtonJustReleased returns a boolean value indicating
+// whether the given mouse button is released just in the current frame.
+func IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	theInputState.m.RLock()
+	r := theInputState.mouseButtonStates[button] == 0 && theInputState.prevMouseButtonStates[button] > 0
+	theInputState.m.RUnlock()
+	return r
+}
+
 // MouseButtonPressDuration returns how long the mouse button is pressed in frames.
 func MouseButtonPressDuration(button ebiten.MouseButton) int {
 	theInputState.m.RLock()
@@ -143,29 +208,117 @@ func MouseButtonPressDuration(button ebiten.MouseButton) int {
 	return s
 }
 
+// WheelDelta returns the x and y offsets of the mouse wheel in the current frame.
+//
+// WheelDelta returns the same values as ebiten.Wheel, but the values are taken
+// at the same timing as the other states in this package.
+func WheelDelta() (xoff, yoff float64) {
+	theInputState.m.RLock()
+	x, y := theInputState.wheelX, theInputState.wheelY
+	theInputState.m.RUnlock()
+	return x, y
+}
+
+// IsWheelJustScrolled returns a boolean value indicating
+// whether the mouse wheel starts being scrolled just in the current frame.
+func IsWheelJustScrolled() bool {
+	return WheelScrollDuration() == 1
+}
+
+// WheelScrollDuration returns how long the mouse wheel is scrolled continuously in frames.
+func WheelScrollDuration() int {
+	theInputState.m.RLock()
+	s := theInputState.wheelState
+	theInputState.m.RUnlock()
+	return s
+}
+
 // IsGamepadButtonJustPressed returns a boolean value indicating
 // whether the given gamepad button of the gamepad id is pressed just in the current frame.
 func IsGamepadButtonJustPressed(id int, button ebiten.GamepadButton) bool {
 	return GamepadButtonPressDuration(id, button) == 1
 }
 
+// IsGamepadButtonJustReleased returns a boolean value indicating
+// whether the given gamepad button of the gamepad id is released just in the current frame.
+//
+// IsGamepadButtonJustReleased also returns true when the gamepad is disconnected just in the current frame
+// while the button is pressed.
+func IsGamepadButtonJustReleased(id int, button ebiten.GamepadButton) bool {
+	theInputState.m.RLock()
+	r := theInputState.gamepadButtonStates[id][button] == 0 && theInputState.prevGamepadButtonStates[id][button] > 0
+	theInputState.m.RUnlock()
+	return r
+}
+
 // GamepadButtonPressDuration returns how long the gamepad button of the gamepad id is pressed in frames.
 func GamepadButtonPressDuration(id int, button ebiten.GamepadButton) int {
 	theInputState.m.RLock()
-	s := 0
-	if _, ok := theInputState.gamepadButtonStates[id]; ok {
-		s = theInputState.gamepadButtonStates[id][button]
-	}
+	s := theInputState.gamepadButtonStates[id][button]
 	theInputState.m.RUnlock()
 	return s
 }
 
+// JustConnectedGamepadIDs returns gamepad IDs that are connected just in the current frame.
+//
+// The returned IDs are sorted in ascending order.
+func JustConnectedGamepadIDs() []int {
+	theInputState.m.RLock()
+	var ids []int
+	for id := range theInputState.gamepadIDs {
+		if _, ok := theInputState.prevGamepadIDs[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	theInputState.m.RUnlock()
+	sort.Ints(ids)
+	return ids
+}
+
+// JustDisconnectedGamepadIDs returns gamepad IDs that are disconnected just in the current frame.
+//
+// The returned IDs are sorted in ascending order.
+func JustDisconnectedGamepadIDs() []int {
+	theInputState.m.RLock()
+	var ids []int
+	for id := range theInputState.prevGamepadIDs {
+		if _, ok := theInputState.gamepadIDs[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	theInputState.m.RUnlock()
+	sort.Ints(ids)
+	return ids
+}
+
+// IsGamepadJustDisconnected returns a boolean value indicating
+// whether the gamepad of the given id is disconnected just in the current frame.
+//
+// The states of the buttons of the disconnected gamepad are already reset in the current frame.
+func IsGamepadJustDisconnected(id int) bool {
+	theInputState.m.RLock()
+	_, prev := theInputState.prevGamepadIDs[id]
+	_, current := theInputState.gamepadIDs[id]
+	theInputState.m.RUnlock()
+	return prev && !current
+}
+
 // IsJustTouched returns a boolean value indicating
 // whether the given touch is pressed just in the current frame.
 func IsJustTouched(id int) bool {
 	return TouchDuration(id) == 1
 }
 
+// IsTouchJustReleased returns a boolean value indicating
+// whether the given touch is released just in the current frame.
+func IsTouchJustReleased(id int) bool {
+	theInputState.m.RLock()
+	_, current := theInputState.touchStates[id]
+	_, prev := theInputState.prevTouchStates[id]
+	theInputState.m.RUnlock()
+	return prev && !current
+}
+
 // TouchDuration returns how long the touch remains in frames.
 func TouchDuration(id int) int {
 	theInputState.m.RLock()
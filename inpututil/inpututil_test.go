@@ -0,0 +1,143 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/inputtest"
+	. "github.com/dave/ebiten/inpututil"
+)
+
+func TestJustReleased(t *testing.T) {
+	defer inputtest.Reset()
+
+	inputtest.PressKey(ebiten.KeyA)
+	inputtest.PressKey(ebiten.KeyZ)
+	inputtest.PressMouseButton(ebiten.MouseButtonLeft)
+	inputtest.PressGamepadButton(0, ebiten.GamepadButton1)
+	inputtest.SetTouch(1, 10, 20)
+	if err := inputtest.Update(func() error {
+		want := []ebiten.Key{ebiten.KeyA, ebiten.KeyZ}
+		if got := AppendJustPressedKeys(nil); !reflect.DeepEqual(got, want) {
+			t.Errorf("AppendJustPressedKeys(nil): got: %v, want: %v", got, want)
+		}
+		if IsKeyJustReleased(ebiten.KeyA) {
+			t.Errorf("IsKeyJustReleased(KeyA): got: true, want: false")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	inputtest.ReleaseKey(ebiten.KeyA)
+	inputtest.ReleaseMouseButton(ebiten.MouseButtonLeft)
+	inputtest.ReleaseGamepadButton(0, ebiten.GamepadButton1)
+	inputtest.ReleaseTouch(1)
+	if err := inputtest.Update(func() error {
+		if got := AppendJustPressedKeys(nil); len(got) != 0 {
+			t.Errorf("AppendJustPressedKeys(nil): got: %v, want: []", got)
+		}
+		if !IsKeyJustReleased(ebiten.KeyA) {
+			t.Errorf("IsKeyJustReleased(KeyA): got: false, want: true")
+		}
+		if IsKeyJustReleased(ebiten.KeyZ) {
+			t.Errorf("IsKeyJustReleased(KeyZ): got: true, want: false")
+		}
+		if !IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+			t.Errorf("IsMouseButtonJustReleased(MouseButtonLeft): got: false, want: true")
+		}
+		if !IsGamepadButtonJustReleased(0, ebiten.GamepadButton1) {
+			t.Errorf("IsGamepadButtonJustReleased(0, GamepadButton1): got: false, want: true")
+		}
+		if !IsTouchJustReleased(1) {
+			t.Errorf("IsTouchJustReleased(1): got: false, want: true")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := inputtest.Update(func() error {
+		if IsKeyJustReleased(ebiten.KeyA) {
+			t.Errorf("IsKeyJustReleased(KeyA) in the next frame: got: true, want: false")
+		}
+		if IsTouchJustReleased(1) {
+			t.Errorf("IsTouchJustReleased(1) in the next frame: got: true, want: false")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGamepadConnection(t *testing.T) {
+	defer inputtest.Reset()
+
+	// Run a tick without gamepads so that the gamepads of the other tests are not reported.
+	inputtest.Reset()
+	if err := inputtest.Update(func() error {
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	inputtest.ConnectGamepad(3)
+	inputtest.ConnectGamepad(5)
+	if err := inputtest.Update(func() error {
+		if got, want := JustConnectedGamepadIDs(), []int{3, 5}; !reflect.DeepEqual(got, want) {
+			t.Errorf("JustConnectedGamepadIDs(): got: %v, want: %v", got, want)
+		}
+		if got := JustDisconnectedGamepadIDs(); len(got) != 0 {
+			t.Errorf("JustDisconnectedGamepadIDs(): got: %v, want: []", got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	inputtest.DisconnectGamepad(5)
+	if err := inputtest.Update(func() error {
+		if got := JustConnectedGamepadIDs(); len(got) != 0 {
+			t.Errorf("JustConnectedGamepadIDs() after disconnecting: got: %v, want: []", got)
+		}
+		if got, want := JustDisconnectedGamepadIDs(), []int{5}; !reflect.DeepEqual(got, want) {
+			t.Errorf("JustDisconnectedGamepadIDs() after disconnecting: got: %v, want: %v", got, want)
+		}
+		if !IsGamepadJustDisconnected(5) {
+			t.Errorf("IsGamepadJustDisconnected(5): got: false, want: true")
+		}
+		if IsGamepadJustDisconnected(3) {
+			t.Errorf("IsGamepadJustDisconnected(3): got: true, want: false")
+		}
+		if got := ebiten.GamepadName(5); got != "" {
+			t.Errorf("GamepadName(5) after disconnecting: got: %q, want: \"\"", got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := inputtest.Update(func() error {
+		if got := JustDisconnectedGamepadIDs(); len(got) != 0 {
+			t.Errorf("JustDisconnectedGamepadIDs() in the next frame: got: %v, want: []", got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
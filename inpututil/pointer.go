@@ -0,0 +1,157 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil
+
+import (
+	"sort"
+
+	"github.com/dave/ebiten"
+)
+
+type position struct {
+	x int
+	y int
+}
+
+// PointerType represents a type of a pointer.
+type PointerType int
+
+// Pointer types
+const (
+	PointerTypeMouse PointerType = iota
+	PointerTypeTouch
+)
+
+// Pointer represents a state of a pointer, i.e., the mouse or a touch.
+//
+// The mouse is treated as a pointer that is pressed while the left mouse button is pressed.
+type Pointer struct {
+	// Type is the type of the pointer.
+	Type PointerType
+
+	// ID is the touch ID for a touch, and 0 for the mouse.
+	ID int
+
+	// X and Y are the position of the pointer.
+	X int
+	Y int
+
+	// Pressed represents whether the pointer is pressed.
+	// A touch pointer is always pressed while it exists.
+	Pressed bool
+
+	// PressDuration is how long the pointer is pressed in frames.
+	// For a pointer returned by JustReleasedPointers, PressDuration is the duration until the previous frame.
+	PressDuration int
+}
+
+// IsJustPressed returns a boolean value indicating
+// whether the pointer is pressed just in the current frame.
+func (p *Pointer) IsJustPressed() bool {
+	return p.PressDuration == 1
+}
+
+// mousePointerAvailable reports whether the mouse is reported as a pointer.
+//
+// While the touch emulation is enabled, the mouse is reported only as a touch.
+func mousePointerAvailable() bool {
+	return hasMouse && !ebiten.IsTouchEmulationEnabled()
+}
+
+// Pointers returns the current pointers.
+//
+// The mouse pointer comes first if available, and the touch pointers follow in ascending order of the IDs.
+// The mouse pointer is available even when no mouse button is pressed,
+// while a touch pointer is available only while the touch exists.
+//
+// The mouse pointer is not available on mobiles, or while the touch emulation is enabled
+// by ebiten.SetTouchEmulationEnabled.
+func Pointers() []Pointer {
+	mouse := mousePointerAvailable()
+
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+
+	var ps []Pointer
+	if mouse {
+		d := theInputState.mouseButtonStates[ebiten.MouseButtonLeft]
+		ps = append(ps, Pointer{
+			Type:          PointerTypeMouse,
+			X:             theInputState.cursorX,
+			Y:             theInputState.cursorY,
+			Pressed:       d > 0,
+			PressDuration: d,
+		})
+	}
+	ids := make([]int, 0, len(theInputState.touchStates))
+	for id := range theInputState.touchStates {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		p := theInputState.touchPositions[id]
+		ps = append(ps, Pointer{
+			Type:          PointerTypeTouch,
+			ID:            id,
+			X:             p.x,
+			Y:             p.y,
+			Pressed:       true,
+			PressDuration: theInputState.touchStates[id],
+		})
+	}
+	return ps
+}
+
+// JustReleasedPointers returns the pointers that are released just in the current frame.
+//
+// The order of the pointers is the same as Pointers.
+// The position of a released touch is the position in the previous frame.
+func JustReleasedPointers() []Pointer {
+	mouse := mousePointerAvailable()
+
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+
+	var ps []Pointer
+	if mouse {
+		d := theInputState.prevMouseButtonStates[ebiten.MouseButtonLeft]
+		if theInputState.mouseButtonStates[ebiten.MouseButtonLeft] == 0 && d > 0 {
+			ps = append(ps, Pointer{
+				Type:          PointerTypeMouse,
+				X:             theInputState.cursorX,
+				Y:             theInputState.cursorY,
+				PressDuration: d,
+			})
+		}
+	}
+	var ids []int
+	for id := range theInputState.prevTouchStates {
+		if _, ok := theInputState.touchStates[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		p := theInputState.prevTouchPositions[id]
+		ps = append(ps, Pointer{
+			Type:          PointerTypeTouch,
+			ID:            id,
+			X:             p.x,
+			Y:             p.y,
+			PressDuration: theInputState.prevTouchStates[id],
+		})
+	}
+	return ps
+}
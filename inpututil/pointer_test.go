@@ -0,0 +1,65 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inpututil_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/inputtest"
+	. "github.com/dave/ebiten/inpututil"
+)
+
+func TestPointers(t *testing.T) {
+	defer inputtest.Reset()
+
+	inputtest.SetCursorPosition(10, 20)
+	inputtest.PressMouseButton(ebiten.MouseButtonLeft)
+	inputtest.SetTouch(3, 30, 40)
+	if err := inputtest.Update(func() error {
+		want := []Pointer{
+			{Type: PointerTypeMouse, X: 10, Y: 20, Pressed: true, PressDuration: 1},
+			{Type: PointerTypeTouch, ID: 3, X: 30, Y: 40, Pressed: true, PressDuration: 1},
+		}
+		if got := Pointers(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Pointers(): got: %v, want: %v", got, want)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	inputtest.ReleaseMouseButton(ebiten.MouseButtonLeft)
+	inputtest.ReleaseTouch(3)
+	if err := inputtest.Update(func() error {
+		want := []Pointer{
+			{Type: PointerTypeMouse, X: 10, Y: 20},
+		}
+		if got := Pointers(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Pointers(): got: %v, want: %v", got, want)
+		}
+		want = []Pointer{
+			{Type: PointerTypeMouse, X: 10, Y: 20, PressDuration: 1},
+			{Type: PointerTypeTouch, ID: 3, X: 30, Y: 40, PressDuration: 1},
+		}
+		if got := JustReleasedPointers(); !reflect.DeepEqual(got, want) {
+			t.Errorf("JustReleasedPointers(): got: %v, want: %v", got, want)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,514 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate file2byteslice -input gamecontrollerdb.txt -output gamecontrollerdb.go -package gamepaddb -var gamecontrollerdbTxt
+//go:generate gofmt -s -w .
+
+// Package gamepaddb manages the gamepad mappings in the format of SDL_GameControllerDB
+// (https://github.com/gabomdq/SDL_GameControllerDB).
+//
+// A mapping converts the raw axes, buttons and hats of a gamepad to the standard layout.
+// Mappings are keyed by the SDL's GUID of a gamepad.
+package gamepaddb
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dave/ebiten/internal/sync"
+)
+
+// StandardButton represents a button in the standard layout.
+//
+// The order is the same as the standard gamepad of the W3C Gamepad API.
+type StandardButton int
+
+const (
+	StandardButtonA StandardButton = iota
+	StandardButtonB
+	StandardButtonX
+	StandardButtonY
+	StandardButtonLeftBumper
+	StandardButtonRightBumper
+	StandardButtonLeftTrigger
+	StandardButtonRightTrigger
+	StandardButtonBack
+	StandardButtonStart
+	StandardButtonLeftStick
+	StandardButtonRightStick
+	StandardButtonDpadUp
+	StandardButtonDpadDown
+	StandardButtonDpadLeft
+	StandardButtonDpadRight
+	StandardButtonGuide
+	StandardButtonMax = StandardButtonGuide
+)
+
+// StandardAxis represents an axis in the standard layout.
+//
+// The order is the same as the standard gamepad of the W3C Gamepad API.
+type StandardAxis int
+
+const (
+	StandardAxisLeftStickX StandardAxis = iota
+	StandardAxisLeftStickY
+	StandardAxisRightStickX
+	StandardAxisRightStickY
+	StandardAxisMax = StandardAxisRightStickY
+)
+
+var sdlButtonNames = map[string]StandardButton{
+	"a":             StandardButtonA,
+	"b":             StandardButtonB,
+	"x":             StandardButtonX,
+	"y":             StandardButtonY,
+	"leftshoulder":  StandardButtonLeftBumper,
+	"rightshoulder": StandardButtonRightBumper,
+	"lefttrigger":   StandardButtonLeftTrigger,
+	"righttrigger":  StandardButtonRightTrigger,
+	"back":          StandardButtonBack,
+	"start":         StandardButtonStart,
+	"leftstick":     StandardButtonLeftStick,
+	"rightstick":    StandardButtonRightStick,
+	"dpup":          StandardButtonDpadUp,
+	"dpdown":        StandardButtonDpadDown,
+	"dpleft":        StandardButtonDpadLeft,
+	"dpright":       StandardButtonDpadRight,
+	"guide":         StandardButtonGuide,
+}
+
+var sdlAxisNames = map[string]StandardAxis{
+	"leftx":  StandardAxisLeftStickX,
+	"lefty":  StandardAxisLeftStickY,
+	"rightx": StandardAxisRightStickX,
+	"righty": StandardAxisRightStickY,
+}
+
+// ButtonPressedThreshold is the threshold of a button value to be treated as pressed.
+const ButtonPressedThreshold = 0.5
+
+// GamepadState represents the raw state of a gamepad.
+type GamepadState interface {
+	// Axis returns the value of the axis in [-1, 1].
+	Axis(index int) float64
+
+	// Button returns true if the button is pressed.
+	Button(index int) bool
+
+	// Hat returns the state of the hat as a bit set: 1 is up, 2 is right, 4 is down and 8 is left.
+	Hat(index int) int
+}
+
+type elementType int
+
+const (
+	elementTypeButton elementType = iota
+	elementTypeAxis
+	elementTypeHat
+)
+
+// element represents an input element of a gamepad like 'b1', '+a2', 'a3~' or 'h0.4'.
+type element struct {
+	typ   elementType
+	index int
+
+	// half is 1 or -1 when only the positive or negative half of the axis is used, otherwise 0.
+	half int
+
+	// inverted is true when the axis is inverted ('~').
+	inverted bool
+
+	// hatMask is the bit mask of the hat state.
+	hatMask int
+}
+
+// unipolar reports whether the value of the element is in [0, 1] instead of [-1, 1].
+func (e *element) unipolar() bool {
+	return e.typ != elementTypeAxis || e.half != 0
+}
+
+func (e *element) value(state GamepadState) float64 {
+	switch e.typ {
+	case elementTypeButton:
+		if state.Button(e.index) {
+			return 1
+		}
+		return 0
+	case elementTypeHat:
+		if state.Hat(e.index)&e.hatMask != 0 {
+			return 1
+		}
+		return 0
+	case elementTypeAxis:
+		v := state.Axis(e.index)
+		if e.inverted {
+			v = -v
+		}
+		switch e.half {
+		case 1:
+			v = math.Max(v, 0)
+		case -1:
+			v = math.Max(-v, 0)
+		}
+		return v
+	default:
+		panic("not reached")
+	}
+}
+
+// buttonValue returns the value of the element in [0, 1].
+func (e *element) buttonValue(state GamepadState) float64 {
+	v := e.value(state)
+	if e.unipolar() {
+		return v
+	}
+	return (v + 1) / 2
+}
+
+// axisValue returns the value of the element in [-1, 1].
+func (e *element) axisValue(state GamepadState) float64 {
+	v := e.value(state)
+	if e.unipolar() {
+		return v*2 - 1
+	}
+	return v
+}
+
+// axisMapping represents the elements mapped to a standard axis.
+type axisMapping struct {
+	// full is the element mapped to the whole axis like 'leftx:a0'.
+	full *element
+
+	// negative and positive are the elements mapped to the halves of the axis like '-leftx:b13'.
+	negative *element
+	positive *element
+}
+
+func (a *axisMapping) value(state GamepadState) float64 {
+	if a.full != nil {
+		return a.full.axisValue(state)
+	}
+	v := 0.0
+	if a.negative != nil {
+		v -= a.negative.buttonValue(state)
+	}
+	if a.positive != nil {
+		v += a.positive.buttonValue(state)
+	}
+	return v
+}
+
+type mapping struct {
+	name    string
+	buttons map[StandardButton]*element
+	axes    map[StandardAxis]*axisMapping
+}
+
+type db struct {
+	mappings map[string]*mapping
+	loaded   bool
+	m        sync.RWMutex
+}
+
+var theDB = &db{
+	mappings: map[string]*mapping{},
+}
+
+func (d *db) load() {
+	d.m.RLock()
+	loaded := d.loaded
+	d.m.RUnlock()
+	if loaded {
+		return
+	}
+
+	var ms map[string]*mapping
+	if useEmbeddedDB {
+		var err error
+		ms, err = parse(gamecontrollerdbTxt)
+		if err != nil {
+			panic(fmt.Sprintf("gamepaddb: parsing the embedded database failed: %v", err))
+		}
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.loaded {
+		return
+	}
+	for id, m := range ms {
+		// Mappings added by Update have priority over the embedded ones.
+		if _, ok := d.mappings[id]; ok {
+			continue
+		}
+		d.mappings[id] = m
+	}
+	d.loaded = true
+}
+
+func (d *db) mapping(guid string) *mapping {
+	d.load()
+	d.m.RLock()
+	defer d.m.RUnlock()
+	return d.mappings[strings.ToLower(guid)]
+}
+
+// Update adds the mappings in the format of SDL_GameControllerDB.
+//
+// Mappings for the other platforms are ignored.
+// An existing mapping with the same GUID is replaced.
+// If an error occurs, no mapping is added.
+func Update(mappings []byte) error {
+	ms, err := parse(mappings)
+	if err != nil {
+		return err
+	}
+	theDB.m.Lock()
+	defer theDB.m.Unlock()
+	for id, m := range ms {
+		theDB.mappings[id] = m
+	}
+	return nil
+}
+
+// HasStandardLayoutMapping reports whether a mapping for the given GUID exists.
+func HasStandardLayoutMapping(guid string) bool {
+	return theDB.mapping(guid) != nil
+}
+
+// Name returns the gamepad's name in the mapping for the given GUID.
+// Name returns an empty string if no mapping exists.
+func Name(guid string) string {
+	m := theDB.mapping(guid)
+	if m == nil {
+		return ""
+	}
+	return m.name
+}
+
+// HasStandardButton reports whether the mapping for the given GUID has the button.
+func HasStandardButton(guid string, button StandardButton) bool {
+	m := theDB.mapping(guid)
+	if m == nil {
+		return false
+	}
+	_, ok := m.buttons[button]
+	return ok
+}
+
+// StandardButtonValue returns the value of the button in [0, 1].
+// StandardButtonValue returns 0 if no mapping for the given GUID or the button exists.
+func StandardButtonValue(guid string, button StandardButton, state GamepadState) float64 {
+	m := theDB.mapping(guid)
+	if m == nil {
+		return 0
+	}
+	e, ok := m.buttons[button]
+	if !ok {
+		return 0
+	}
+	return e.buttonValue(state)
+}
+
+// IsStandardButtonPressed reports whether the button is pressed.
+// IsStandardButtonPressed returns false if no mapping for the given GUID or the button exists.
+func IsStandardButtonPressed(guid string, button StandardButton, state GamepadState) bool {
+	return StandardButtonValue(guid, button, state) > ButtonPressedThreshold
+}
+
+// StandardAxisValue returns the value of the axis in [-1, 1].
+// StandardAxisValue returns 0 if no mapping for the given GUID or the axis exists.
+func StandardAxisValue(guid string, axis StandardAxis, state GamepadState) float64 {
+	m := theDB.mapping(guid)
+	if m == nil {
+		return 0
+	}
+	a, ok := m.axes[axis]
+	if !ok {
+		return 0
+	}
+	return a.value(state)
+}
+
+func parse(data []byte) (map[string]*mapping, error) {
+	ms := map[string]*mapping{}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	n := 0
+	for s.Scan() {
+		n++
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		id, m, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("gamepaddb: line %d: %v", n, err)
+		}
+		if m == nil {
+			continue
+		}
+		ms[id] = m
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// parseLine parses one line of SDL_GameControllerDB.
+// parseLine returns a nil mapping without an error when the line is for another platform.
+func parseLine(line string) (string, *mapping, error) {
+	tokens := strings.Split(line, ",")
+	if len(tokens) < 2 {
+		return "", nil, fmt.Errorf("too few fields: %q", line)
+	}
+	id := strings.ToLower(tokens[0])
+	if len(id) != 32 {
+		return "", nil, fmt.Errorf("invalid GUID: %q", tokens[0])
+	}
+	if _, err := strconv.ParseUint(id[:16], 16, 64); err != nil {
+		return "", nil, fmt.Errorf("invalid GUID: %q", tokens[0])
+	}
+	if _, err := strconv.ParseUint(id[16:], 16, 64); err != nil {
+		return "", nil, fmt.Errorf("invalid GUID: %q", tokens[0])
+	}
+
+	m := &mapping{
+		name:    tokens[1],
+		buttons: map[StandardButton]*element{},
+		axes:    map[StandardAxis]*axisMapping{},
+	}
+	for _, token := range tokens[2:] {
+		if token == "" {
+			continue
+		}
+		ts := strings.SplitN(token, ":", 2)
+		if len(ts) != 2 {
+			return "", nil, fmt.Errorf("invalid field: %q", token)
+		}
+		key, value := ts[0], ts[1]
+		if key == "platform" {
+			if value != currentPlatform {
+				return "", nil, nil
+			}
+			continue
+		}
+
+		half := 0
+		if key[0] == '+' || key[0] == '-' {
+			half = 1
+			if key[0] == '-' {
+				half = -1
+			}
+			key = key[1:]
+		}
+
+		if b, ok := sdlButtonNames[key]; ok {
+			if half != 0 {
+				return "", nil, fmt.Errorf("a button can't be a half axis: %q", token)
+			}
+			e, err := parseElement(value)
+			if err != nil {
+				return "", nil, err
+			}
+			m.buttons[b] = e
+			continue
+		}
+		if a, ok := sdlAxisNames[key]; ok {
+			e, err := parseElement(value)
+			if err != nil {
+				return "", nil, err
+			}
+			am, ok := m.axes[a]
+			if !ok {
+				am = &axisMapping{}
+				m.axes[a] = am
+			}
+			switch half {
+			case 0:
+				am.full = e
+			case 1:
+				am.positive = e
+			case -1:
+				am.negative = e
+			}
+			continue
+		}
+		// Ignore unknown keys like 'misc1', 'paddle1' or 'hint'.
+	}
+	return id, m, nil
+}
+
+func parseElement(str string) (*element, error) {
+	if str == "" {
+		return nil, fmt.Errorf("empty element")
+	}
+	e := &element{}
+	switch {
+	case str[0] == 'b':
+		i, err := strconv.Atoi(str[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid button: %q", str)
+		}
+		e.typ = elementTypeButton
+		e.index = i
+		return e, nil
+	case str[0] == 'h':
+		ts := strings.SplitN(str[1:], ".", 2)
+		if len(ts) != 2 {
+			return nil, fmt.Errorf("invalid hat: %q", str)
+		}
+		i, err := strconv.Atoi(ts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hat: %q", str)
+		}
+		mask, err := strconv.Atoi(ts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hat: %q", str)
+		}
+		e.typ = elementTypeHat
+		e.index = i
+		e.hatMask = mask
+		return e, nil
+	case str[0] == 'a' || str[0] == '+' || str[0] == '-':
+		s := str
+		switch s[0] {
+		case '+':
+			e.half = 1
+			s = s[1:]
+		case '-':
+			e.half = -1
+			s = s[1:]
+		}
+		if strings.HasSuffix(s, "~") {
+			e.inverted = true
+			s = s[:len(s)-1]
+		}
+		if len(s) == 0 || s[0] != 'a' {
+			return nil, fmt.Errorf("invalid axis: %q", str)
+		}
+		i, err := strconv.Atoi(s[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid axis: %q", str)
+		}
+		e.typ = elementTypeAxis
+		e.index = i
+		return e, nil
+	}
+	return nil, fmt.Errorf("invalid element: %q", str)
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb_test
+
+import (
+	"testing"
+
+	. "github.com/dave/ebiten/internal/gamepaddb"
+)
+
+type testGamepad struct {
+	axes    []float64
+	buttons []bool
+	hats    []int
+}
+
+func (g *testGamepad) Axis(index int) float64 {
+	return g.axes[index]
+}
+
+func (g *testGamepad) Button(index int) bool {
+	return g.buttons[index]
+}
+
+func (g *testGamepad) Hat(index int) int {
+	return g.hats[index]
+}
+
+func TestUpdate(t *testing.T) {
+	const guid = "ffffffffffffffffffffffffffff0001"
+	if HasStandardLayoutMapping(guid) {
+		t.Fatalf("HasStandardLayoutMapping(%q): got: true, want: false", guid)
+	}
+	mappings := guid + ",Test Gamepad,a:b1,b:b0,dpup:h0.1,dpleft:-a2,lefttrigger:a3,righttrigger:+a4,leftx:a0,lefty:a1~,-rightx:b2,+rightx:b3,"
+	if err := Update([]byte(mappings)); err != nil {
+		t.Fatal(err)
+	}
+	if !HasStandardLayoutMapping(guid) {
+		t.Fatalf("HasStandardLayoutMapping(%q): got: false, want: true", guid)
+	}
+	if got, want := Name(guid), "Test Gamepad"; got != want {
+		t.Errorf("Name(%q): got: %q, want: %q", guid, got, want)
+	}
+
+	g := &testGamepad{
+		axes:    []float64{0.5, 0.25, -1, -1, 0.5},
+		buttons: []bool{false, true, false, true},
+		hats:    []int{1 | 2},
+	}
+	buttons := []struct {
+		button StandardButton
+		value  float64
+	}{
+		{StandardButtonA, 1},
+		{StandardButtonB, 0},
+		{StandardButtonDpadUp, 1},
+		{StandardButtonDpadLeft, 1},
+		{StandardButtonLeftTrigger, 0},
+		{StandardButtonRightTrigger, 0.5},
+		{StandardButtonGuide, 0},
+	}
+	for _, c := range buttons {
+		got := StandardButtonValue(guid, c.button, g)
+		if got != c.value {
+			t.Errorf("StandardButtonValue(%d): got: %f, want: %f", c.button, got, c.value)
+		}
+	}
+	axes := []struct {
+		axis  StandardAxis
+		value float64
+	}{
+		{StandardAxisLeftStickX, 0.5},
+		{StandardAxisLeftStickY, -0.25},
+		{StandardAxisRightStickX, 1},
+		{StandardAxisRightStickY, 0},
+	}
+	for _, c := range axes {
+		got := StandardAxisValue(guid, c.axis, g)
+		if got != c.value {
+			t.Errorf("StandardAxisValue(%d): got: %f, want: %f", c.axis, got, c.value)
+		}
+	}
+}
+
+func TestUpdateError(t *testing.T) {
+	const guid = "ffffffffffffffffffffffffffff0002"
+	mappings := guid + ",Valid Gamepad,a:b0,\ninvalid,Invalid Gamepad,a:b0,"
+	if err := Update([]byte(mappings)); err == nil {
+		t.Errorf("Update must return an error for an invalid GUID")
+	}
+	if HasStandardLayoutMapping(guid) {
+		t.Errorf("HasStandardLayoutMapping(%q): got: true, want: false", guid)
+	}
+}
+
+func TestEmbeddedDB(t *testing.T) {
+	// Parsing the embedded database must not panic.
+	HasStandardLayoutMapping("00000000000000000000000000000000")
+}
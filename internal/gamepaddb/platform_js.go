@@ -0,0 +1,25 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build js
+
+package gamepaddb
+
+// Browsers are not a platform in SDL_GameControllerDB, and the raw indices on browsers
+// differ from the native ones. Only mappings without the platform field are used.
+const currentPlatform = ""
+
+// useEmbeddedDB represents whether the embedded database is used.
+// All the mappings in the embedded database have the platform field, so they never apply on browsers.
+const useEmbeddedDB = false
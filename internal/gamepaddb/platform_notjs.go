@@ -0,0 +1,43 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !js
+
+package gamepaddb
+
+import (
+	"runtime"
+)
+
+// currentPlatform is the platform name used in the 'platform' field of SDL_GameControllerDB.
+var currentPlatform = platform()
+
+// useEmbeddedDB represents whether the embedded database is used.
+const useEmbeddedDB = true
+
+func platform() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "Windows"
+	case "darwin":
+		if runtime.GOARCH == "arm" || runtime.GOARCH == "arm64" {
+			return "iOS"
+		}
+		return "Mac OS X"
+	case "android":
+		return "Android"
+	default:
+		return "Linux"
+	}
+}
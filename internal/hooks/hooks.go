@@ -14,7 +14,10 @@
 
 package hooks
 
-var onUpdateHooks = []func() error{}
+var (
+	onUpdateHooks      = []func() error{}
+	onAfterUpdateHooks = []func() error{}
+)
 
 // AppendHookOnUpdate appends a hook function that is run before the main update function
 // every frame.
@@ -30,3 +33,18 @@ func Run() error {
 	}
 	return nil
 }
+
+// AppendHookOnAfterUpdate appends a hook function that is run after the main update function
+// every frame, before the input states valid only in the frame are reset.
+func AppendHookOnAfterUpdate(f func() error) {
+	onAfterUpdateHooks = append(onAfterUpdateHooks, f)
+}
+
+func RunAfterUpdate() error {
+	for _, f := range onAfterUpdateHooks {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"github.com/dave/ebiten/internal/sync"
+)
+
+// memoryClipboard is an in-memory stand-in for the system clipboard.
+//
+// memoryClipboard is used when the system clipboard is not available:
+// on mobiles, on Node.js, and on desktops while the game is not running (headless mode).
+// The text is shared only within the process.
+type memoryClipboard struct {
+	text string
+	m    sync.Mutex
+}
+
+var theMemoryClipboard = &memoryClipboard{}
+
+func (c *memoryClipboard) get() string {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.text
+}
+
+func (c *memoryClipboard) set(text string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.text = text
+}
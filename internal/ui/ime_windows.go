@@ -0,0 +1,131 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !js
+
+package ui
+
+import (
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	gcsCompStr      = 0x0008
+	gcsCursorPos    = 0x0080
+	cfsPoint        = 0x0002
+	cfsCandidatePos = 0x0040
+)
+
+var (
+	imm32 = windows.NewLazySystemDLL("imm32.dll")
+
+	getActiveWindowProc          = user32.NewProc("GetActiveWindow")
+	immGetContextProc            = imm32.NewProc("ImmGetContext")
+	immReleaseContextProc        = imm32.NewProc("ImmReleaseContext")
+	immGetCompositionStringWProc = imm32.NewProc("ImmGetCompositionStringW")
+	immSetCompositionWindowProc  = imm32.NewProc("ImmSetCompositionWindow")
+	immSetCandidateWindowProc    = imm32.NewProc("ImmSetCandidateWindow")
+)
+
+type point struct {
+	x int32
+	y int32
+}
+
+type rect struct {
+	left   int32
+	top    int32
+	right  int32
+	bottom int32
+}
+
+type compositionForm struct {
+	dwStyle      uint32
+	ptCurrentPos point
+	rcArea       rect
+}
+
+type candidateForm struct {
+	dwIndex      uint32
+	dwStyle      uint32
+	ptCurrentPos point
+	rcArea       rect
+}
+
+// withIMEContext calls f with the input context of the active window.
+// withIMEContext must be called on the main thread.
+func withIMEContext(f func(hwnd, himc uintptr)) {
+	hwnd, _, _ := syscall.Syscall(getActiveWindowProc.Addr(), 0, 0, 0, 0)
+	if hwnd == 0 {
+		return
+	}
+	himc, _, _ := syscall.Syscall(immGetContextProc.Addr(), 1, hwnd, 0, 0)
+	if himc == 0 {
+		return
+	}
+	defer syscall.Syscall(immReleaseContextProc.Addr(), 2, hwnd, himc, 0)
+	f(hwnd, himc)
+}
+
+// imeComposition returns the composition string and the caret position in runes.
+func imeComposition() (string, int) {
+	text := ""
+	caret := 0
+	withIMEContext(func(hwnd, himc uintptr) {
+		// The size is in bytes. A negative value means an error.
+		r, _, _ := syscall.Syscall6(immGetCompositionStringWProc.Addr(), 4, himc, gcsCompStr, 0, 0, 0, 0)
+		n := int32(r)
+		if n <= 0 {
+			return
+		}
+		buf := make([]uint16, n/2)
+		syscall.Syscall6(immGetCompositionStringWProc.Addr(), 4, himc, gcsCompStr, uintptr(unsafe.Pointer(&buf[0])), uintptr(n), 0, 0)
+		text = string(utf16.Decode(buf))
+
+		// The caret position is in UTF-16.
+		r, _, _ = syscall.Syscall6(immGetCompositionStringWProc.Addr(), 4, himc, gcsCursorPos, 0, 0, 0, 0)
+		c := int(int32(r))
+		if c < 0 {
+			c = 0
+		}
+		if c > len(buf) {
+			c = len(buf)
+		}
+		caret = len(utf16.Decode(buf[:c]))
+	})
+	return text, caret
+}
+
+// setIMECandidateWindowPosition moves the composition window and the candidate window to (x, y)
+// in the client area's pixels.
+func setIMECandidateWindowPosition(x, y int) {
+	withIMEContext(func(hwnd, himc uintptr) {
+		p := point{x: int32(x), y: int32(y)}
+		comp := compositionForm{
+			dwStyle:      cfsPoint,
+			ptCurrentPos: p,
+		}
+		syscall.Syscall(immSetCompositionWindowProc.Addr(), 2, himc, uintptr(unsafe.Pointer(&comp)), 0)
+		cand := candidateForm{
+			dwIndex:      0,
+			dwStyle:      cfsCandidatePos,
+			ptCurrentPos: p,
+		}
+		syscall.Syscall(immSetCandidateWindowProc.Addr(), 2, himc, uintptr(unsafe.Pointer(&cand)), 0)
+	})
+}
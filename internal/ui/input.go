@@ -14,6 +14,15 @@
 
 package ui
 
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/dave/ebiten/internal/gamepaddb"
+)
+
 var currentInput = &Input{}
 
 type Touch interface {
@@ -21,6 +30,12 @@ type Touch interface {
 	Position() (x, y int)
 }
 
+type DroppedFile interface {
+	Name() string
+	Position() (x, y int)
+	Open() (io.ReadCloser, error)
+}
+
 func CurrentInput() *Input {
 	return currentInput
 }
@@ -28,14 +43,48 @@ func CurrentInput() *Input {
 func (i *Input) CursorPosition() (x, y int) {
 	i.m.RLock()
 	defer i.m.RUnlock()
+	if o := theInputOverride; o != nil {
+		return o.cursorX, o.cursorY
+	}
 	return adjustCursorPosition(i.cursorX, i.cursorY)
 }
 
+func (i *Input) Wheel() (xoff, yoff float64) {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if o := theInputOverride; o != nil {
+		return o.wheelX, o.wheelY
+	}
+	return i.wheelX, i.wheelY
+}
+
+func (i *Input) DroppedFiles() []DroppedFile {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	fs := make([]DroppedFile, len(i.droppedFiles))
+	for n, f := range i.droppedFiles {
+		f := f
+		f.x, f.y = adjustCursorPosition(f.x, f.y)
+		fs[n] = &f
+	}
+	return fs
+}
+
+// resetForFrame resets the input states that are valid only in one frame.
+// This must be called after every frame update.
+func (i *Input) resetForFrame() {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.wheelX, i.wheelY = 0, 0
+	i.droppedFiles = i.droppedFiles[:0]
+	theInputEventQueue.reset()
+}
+
 func (i *Input) GamepadIDs() []int {
 	i.m.RLock()
 	defer i.m.RUnlock()
 	r := []int{}
-	for id, g := range i.gamepads {
+	for id, g := range i.currentGamepads() {
 		if g.valid {
 			r = append(r, id)
 		}
@@ -43,58 +92,194 @@ func (i *Input) GamepadIDs() []int {
 	return r
 }
 
+func (i *Input) GamepadSDLID(id int) string {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if len(i.currentGamepads()) <= id {
+		return ""
+	}
+	g := i.currentGamepads()[id]
+	if !g.valid {
+		return ""
+	}
+	return g.guid
+}
+
+func (i *Input) GamepadName(id int) string {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if len(i.currentGamepads()) <= id {
+		return ""
+	}
+	g := i.currentGamepads()[id]
+	if !g.valid {
+		return ""
+	}
+	return g.name
+}
+
 func (i *Input) GamepadAxisNum(id int) int {
 	i.m.RLock()
 	defer i.m.RUnlock()
-	if len(i.gamepads) <= id {
+	if len(i.currentGamepads()) <= id {
 		return 0
 	}
-	return i.gamepads[id].axisNum
+	return i.currentGamepads()[id].axisNum
 }
 
 func (i *Input) GamepadAxis(id int, axis int) float64 {
 	i.m.RLock()
 	defer i.m.RUnlock()
-	if len(i.gamepads) <= id {
+	if len(i.currentGamepads()) <= id {
 		return 0
 	}
-	return i.gamepads[id].axes[axis]
+	return i.currentGamepads()[id].axes[axis]
 }
 
 func (i *Input) GamepadButtonNum(id int) int {
 	i.m.RLock()
 	defer i.m.RUnlock()
-	if len(i.gamepads) <= id {
+	if len(i.currentGamepads()) <= id {
 		return 0
 	}
-	return i.gamepads[id].buttonNum
+	return i.currentGamepads()[id].buttonNum
 }
 
 func (i *Input) IsGamepadButtonPressed(id int, button GamepadButton) bool {
 	i.m.RLock()
 	defer i.m.RUnlock()
-	if len(i.gamepads) <= id {
+	if len(i.currentGamepads()) <= id {
+		return false
+	}
+	return i.currentGamepads()[id].buttonPressed[button]
+}
+
+func (i *Input) IsStandardGamepadLayoutAvailable(id int) bool {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if len(i.currentGamepads()) <= id {
 		return false
 	}
-	return i.gamepads[id].buttonPressed[button]
+	return i.currentGamepads()[id].hasStandardLayoutMapping()
+}
+
+func (i *Input) StandardGamepadAxisValue(id int, axis gamepaddb.StandardAxis) float64 {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if len(i.currentGamepads()) <= id {
+		return 0
+	}
+	return i.currentGamepads()[id].standardAxisValue(axis)
+}
+
+func (i *Input) StandardGamepadButtonValue(id int, button gamepaddb.StandardButton) float64 {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if len(i.currentGamepads()) <= id {
+		return 0
+	}
+	return i.currentGamepads()[id].standardButtonValue(button)
+}
+
+func (i *Input) IsStandardGamepadButtonPressed(id int, button gamepaddb.StandardButton) bool {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if len(i.currentGamepads()) <= id {
+		return false
+	}
+	return i.currentGamepads()[id].isStandardButtonPressed(button)
 }
 
 func (in *Input) Touches() []Touch {
+	et, emulated := in.emulatedTouch()
+
 	in.m.RLock()
 	defer in.m.RUnlock()
-	t := make([]Touch, len(in.touches))
+	ts := in.touches
+	if o := theInputOverride; o != nil {
+		ts = o.touches
+	} else if emulated {
+		ts = append(append(make([]touch, 0, len(ts)+1), ts...), et)
+	}
+	t := make([]Touch, len(ts))
 	for i := 0; i < len(t); i++ {
-		t[i] = &in.touches[i]
+		t[i] = &ts[i]
 	}
 	return t
 }
 
 type gamePad struct {
 	valid         bool
+	guid          string
+	name          string
 	axisNum       int
 	axes          [16]float64
 	buttonNum     int
 	buttonPressed [256]bool
+	buttonValues  [256]float64
+	hatNum        int
+	hats          [16]int
+
+	// standard is true when the raw layout is already the standard one (browsers only).
+	standard bool
+}
+
+// Axis implements gamepaddb.GamepadState.
+func (g *gamePad) Axis(index int) float64 {
+	if index < 0 || g.axisNum <= index || len(g.axes) <= index {
+		return 0
+	}
+	return g.axes[index]
+}
+
+// Button implements gamepaddb.GamepadState.
+func (g *gamePad) Button(index int) bool {
+	if index < 0 || g.buttonNum <= index || len(g.buttonPressed) <= index {
+		return false
+	}
+	return g.buttonPressed[index]
+}
+
+// Hat implements gamepaddb.GamepadState.
+func (g *gamePad) Hat(index int) int {
+	if index < 0 || g.hatNum <= index || len(g.hats) <= index {
+		return 0
+	}
+	return g.hats[index]
+}
+
+func (g *gamePad) hasStandardLayoutMapping() bool {
+	return g.standard || gamepaddb.HasStandardLayoutMapping(g.guid)
+}
+
+// useMapping reports whether the gamepad's raw layout needs to be converted with the mapping.
+// If not, the raw indices are used as the standard ones as they are.
+func (g *gamePad) useMapping() bool {
+	return !g.standard && gamepaddb.HasStandardLayoutMapping(g.guid)
+}
+
+func (g *gamePad) standardAxisValue(axis gamepaddb.StandardAxis) float64 {
+	if g.useMapping() {
+		return gamepaddb.StandardAxisValue(g.guid, axis, g)
+	}
+	return g.Axis(int(axis))
+}
+
+func (g *gamePad) standardButtonValue(button gamepaddb.StandardButton) float64 {
+	if g.useMapping() {
+		return gamepaddb.StandardButtonValue(g.guid, button, g)
+	}
+	if int(button) < 0 || g.buttonNum <= int(button) || len(g.buttonValues) <= int(button) {
+		return 0
+	}
+	return g.buttonValues[button]
+}
+
+func (g *gamePad) isStandardButtonPressed(button gamepaddb.StandardButton) bool {
+	if g.useMapping() {
+		return gamepaddb.IsStandardButtonPressed(g.guid, button, g)
+	}
+	return g.Button(int(button))
 }
 
 type touch struct {
@@ -110,3 +295,25 @@ func (t *touch) ID() int {
 func (t *touch) Position() (x, y int) {
 	return t.x, t.y
 }
+
+type droppedFile struct {
+	name string
+	x    int
+	y    int
+	data []byte // Only on browsers, the content is read when the file is dropped.
+}
+
+func (f *droppedFile) Name() string {
+	return f.name
+}
+
+func (f *droppedFile) Position() (x, y int) {
+	return f.x, f.y
+}
+
+func (f *droppedFile) Open() (io.ReadCloser, error) {
+	if f.data != nil {
+		return ioutil.NopCloser(bytes.NewReader(f.data)), nil
+	}
+	return os.Open(f.name)
+}
@@ -23,7 +23,7 @@ import (
 	"sync"
 	"unicode"
 
-	glfw "github.com/go-gl/glfw/v3.2/glfw"
+	glfw "github.com/go-gl/glfw/v3.3/glfw"
 )
 
 type Input struct {
@@ -31,8 +31,13 @@ type Input struct {
 	mouseButtonPressed map[glfw.MouseButton]bool
 	cursorX            int
 	cursorY            int
+	wheelX             float64
+	wheelY             float64
+	drops              []glfwDrop
+	events             []glfwInputEvent
+	droppedFiles       []droppedFile
 	gamepads           [16]gamePad
-	touches            []touch // This is not updated until GLFW 3.3 is available (#417)
+	touches            []touch
 	runeBuffer         []rune
 	m                  sync.RWMutex
 }
@@ -40,21 +45,33 @@ type Input struct {
 func (i *Input) RuneBuffer() []rune {
 	i.m.RLock()
 	defer i.m.RUnlock()
+	if o := theInputOverride; o != nil {
+		return o.chars
+	}
 	return i.runeBuffer
 }
 
 func (i *Input) IsKeyPressed(key Key) bool {
 	i.m.RLock()
 	defer i.m.RUnlock()
+	if o := theInputOverride; o != nil {
+		return o.isKeyPressed(key)
+	}
 	if i.keyPressed == nil {
 		i.keyPressed = map[glfw.Key]bool{}
 	}
+	keys := []Key{key}
+	if ks, ok := modKeyToSideKeys[key]; ok {
+		keys = ks
+	}
 	for gk, k := range glfwKeyCodeToKey {
-		if k != key {
-			continue
-		}
-		if i.keyPressed[gk] {
-			return true
+		for _, kk := range keys {
+			if k != kk {
+				continue
+			}
+			if i.keyPressed[gk] {
+				return true
+			}
 		}
 	}
 	return false
@@ -63,6 +80,9 @@ func (i *Input) IsKeyPressed(key Key) bool {
 func (i *Input) IsMouseButtonPressed(button MouseButton) bool {
 	i.m.RLock()
 	defer i.m.RUnlock()
+	if o := theInputOverride; o != nil {
+		return o.isMouseButtonPressed(button)
+	}
 	if i.mouseButtonPressed == nil {
 		i.mouseButtonPressed = map[glfw.MouseButton]bool{}
 	}
@@ -77,12 +97,38 @@ func (i *Input) IsMouseButtonPressed(button MouseButton) bool {
 	return false
 }
 
+// glfwDrop represents file paths dropped at the cursor position in GLFW's window coordinates.
+type glfwDrop struct {
+	names []string
+	x     float64
+	y     float64
+}
+
+// glfwInputEvent represents an input event with the cursor position in GLFW's window coordinates.
+type glfwInputEvent struct {
+	event InputEvent
+	x     float64
+	y     float64
+}
+
 var glfwMouseButtonToMouseButton = map[glfw.MouseButton]MouseButton{
 	glfw.MouseButtonLeft:   MouseButtonLeft,
 	glfw.MouseButtonRight:  MouseButtonRight,
 	glfw.MouseButtonMiddle: MouseButtonMiddle,
 }
 
+// addEvent records the event to add it to the queue with the converted position at update.
+// GLFW doesn't tell the time of an event. The time when the callback is called is used instead.
+func (i *Input) addEvent(e InputEvent, x, y float64) {
+	if !theInputEventQueue.isEnabled() {
+		return
+	}
+	e.Time = monotonicTime()
+	i.m.Lock()
+	i.events = append(i.events, glfwInputEvent{event: e, x: x, y: y})
+	i.m.Unlock()
+}
+
 func (i *Input) update(window *glfw.Window, scale float64) {
 	i.m.Lock()
 	defer i.m.Unlock()
@@ -93,8 +139,50 @@ func (i *Input) update(window *glfw.Window, scale float64) {
 				i.m.Lock()
 				i.runeBuffer = append(i.runeBuffer, char)
 				i.m.Unlock()
+				i.addEvent(InputEvent{Type: InputEventTypeChar, Char: char}, 0, 0)
 			}
 		})
+		window.SetScrollCallback(func(w *glfw.Window, xoff float64, yoff float64) {
+			i.m.Lock()
+			i.wheelX += xoff
+			i.wheelY += yoff
+			i.m.Unlock()
+			x, y := w.GetCursorPos()
+			i.addEvent(InputEvent{Type: InputEventTypeWheel, WheelX: xoff, WheelY: yoff}, x, y)
+		})
+		window.SetKeyCallback(func(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+			k, ok := glfwKeyCodeToKey[key]
+			if !ok || action == glfw.Repeat {
+				return
+			}
+			t := InputEventTypeKeyDown
+			if action == glfw.Release {
+				t = InputEventTypeKeyUp
+			}
+			i.addEvent(InputEvent{Type: t, Key: k}, 0, 0)
+		})
+		window.SetMouseButtonCallback(func(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+			b, ok := glfwMouseButtonToMouseButton[button]
+			if !ok {
+				return
+			}
+			t := InputEventTypeMouseButtonDown
+			if action == glfw.Release {
+				t = InputEventTypeMouseButtonUp
+			}
+			x, y := w.GetCursorPos()
+			i.addEvent(InputEvent{Type: t, MouseButton: b}, x, y)
+		})
+		window.SetCursorPosCallback(func(w *glfw.Window, x, y float64) {
+			i.addEvent(InputEvent{Type: InputEventTypeMouseMove}, x, y)
+		})
+		window.SetDropCallback(func(w *glfw.Window, names []string) {
+			// GLFW doesn't tell the drop position. Use the cursor position instead.
+			x, y := w.GetCursorPos()
+			i.m.Lock()
+			i.drops = append(i.drops, glfwDrop{names: names, x: x, y: y})
+			i.m.Unlock()
+		})
 	}
 	if i.keyPressed == nil {
 		i.keyPressed = map[glfw.Key]bool{}
@@ -111,14 +199,32 @@ func (i *Input) update(window *glfw.Window, scale float64) {
 	x, y := window.GetCursorPos()
 	i.cursorX = int(x / scale)
 	i.cursorY = int(y / scale)
+	for _, d := range i.drops {
+		for _, n := range d.names {
+			i.droppedFiles = append(i.droppedFiles, droppedFile{
+				name: n,
+				x:    int(d.x / scale),
+				y:    int(d.y / scale),
+			})
+		}
+	}
+	i.drops = nil
+	for _, e := range i.events {
+		e.event.X = int(e.x / scale)
+		e.event.Y = int(e.y / scale)
+		theInputEventQueue.add(e.event)
+	}
+	i.events = nil
 	for id := glfw.Joystick(0); id < glfw.Joystick(len(i.gamepads)); id++ {
 		i.gamepads[id].valid = false
-		if !glfw.JoystickPresent(id) {
+		if !id.Present() {
 			continue
 		}
 		i.gamepads[id].valid = true
+		i.gamepads[id].guid = id.GetGUID()
+		i.gamepads[id].name = id.GetName()
 
-		axes32 := glfw.GetJoystickAxes(id)
+		axes32 := id.GetAxes()
 		i.gamepads[id].axisNum = len(axes32)
 		for a := 0; a < len(i.gamepads[id].axes); a++ {
 			if len(axes32) <= a {
@@ -127,14 +233,32 @@ func (i *Input) update(window *glfw.Window, scale float64) {
 			}
 			i.gamepads[id].axes[a] = float64(axes32[a])
 		}
-		buttons := glfw.GetJoystickButtons(id)
+		// Hats are also reported as buttons after the other buttons.
+		buttons := id.GetButtons()
 		i.gamepads[id].buttonNum = len(buttons)
 		for b := 0; b < len(i.gamepads[id].buttonPressed); b++ {
 			if len(buttons) <= b {
 				i.gamepads[id].buttonPressed[b] = false
+				i.gamepads[id].buttonValues[b] = 0
+				continue
+			}
+			pressed := buttons[b] == glfw.Press
+			i.gamepads[id].buttonPressed[b] = pressed
+			if pressed {
+				i.gamepads[id].buttonValues[b] = 1
+			} else {
+				i.gamepads[id].buttonValues[b] = 0
+			}
+		}
+
+		hats := id.GetHats()
+		i.gamepads[id].hatNum = len(hats)
+		for h := 0; h < len(i.gamepads[id].hats); h++ {
+			if len(hats) <= h {
+				i.gamepads[id].hats[h] = 0
 				continue
 			}
-			i.gamepads[id].buttonPressed[b] = glfw.Action(buttons[b]) == glfw.Press
+			i.gamepads[id].hats[h] = int(hats[h])
 		}
 	}
 }
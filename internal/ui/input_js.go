@@ -17,6 +17,10 @@
 package ui
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+
 	"github.com/gopherjs/gopherjs/js"
 )
 
@@ -33,6 +37,9 @@ type Input struct {
 	mouseButtonPressed map[int]bool
 	cursorX            int
 	cursorY            int
+	wheelX             float64
+	wheelY             float64
+	droppedFiles       []droppedFile
 	gamepads           [16]gamePad
 	touches            []touch
 	runeBuffer         []rune
@@ -40,10 +47,16 @@ type Input struct {
 }
 
 func (i *Input) RuneBuffer() []rune {
+	if o := theInputOverride; o != nil {
+		return o.chars
+	}
 	return i.runeBuffer
 }
 
 func (i *Input) IsKeyPressed(key Key) bool {
+	if o := theInputOverride; o != nil {
+		return o.isKeyPressed(key)
+	}
 	if i.keyPressed != nil {
 		for _, c := range keyToCodes[key] {
 			if i.keyPressed[c] {
@@ -71,6 +84,9 @@ var codeToMouseButton = map[int]MouseButton{
 }
 
 func (i *Input) IsMouseButtonPressed(button MouseButton) bool {
+	if o := theInputOverride; o != nil {
+		return o.isMouseButtonPressed(button)
+	}
 	if i.mouseButtonPressed == nil {
 		i.mouseButtonPressed = map[int]bool{}
 	}
@@ -131,6 +147,20 @@ func (i *Input) setMouseCursor(x, y int) {
 	i.cursorX, i.cursorY = x, y
 }
 
+func (i *Input) addWheel(xoff, yoff float64) {
+	i.wheelX += xoff
+	i.wheelY += yoff
+}
+
+func (i *Input) addDroppedFile(name string, x, y int, data []byte) {
+	i.droppedFiles = append(i.droppedFiles, droppedFile{
+		name: name,
+		x:    x,
+		y:    y,
+		data: data,
+	})
+}
+
 func (i *Input) updateGamepads() {
 	nav := js.Global.Get("navigator")
 	if nav.Get("getGamepads") == js.Undefined {
@@ -145,6 +175,9 @@ func (i *Input) updateGamepads() {
 			continue
 		}
 		i.gamepads[id].valid = true
+		i.gamepads[id].name = gamepad.Get("id").String()
+		i.gamepads[id].guid = gamepadGUID(i.gamepads[id].name)
+		i.gamepads[id].standard = gamepad.Get("mapping").String() == "standard"
 
 		axes := gamepad.Get("axes")
 		axesNum := axes.Get("length").Int()
@@ -163,13 +196,46 @@ func (i *Input) updateGamepads() {
 		for b := 0; b < len(i.gamepads[id].buttonPressed); b++ {
 			if buttonsNum <= b {
 				i.gamepads[id].buttonPressed[b] = false
+				i.gamepads[id].buttonValues[b] = 0
 				continue
 			}
 			i.gamepads[id].buttonPressed[b] = buttons.Index(b).Get("pressed").Bool()
+			i.gamepads[id].buttonValues[b] = buttons.Index(b).Get("value").Float()
 		}
 	}
 }
 
+var (
+	// Chrome's gamepad ID is like "Xbox 360 Controller (XInput STANDARD GAMEPAD Vendor: 045e Product: 028e)".
+	chromeGamepadIDPattern = regexp.MustCompile(`Vendor: ([0-9a-fA-F]{4}) Product: ([0-9a-fA-F]{4})`)
+
+	// Firefox's gamepad ID is like "045e-028e-Xbox 360 Wired Controller".
+	firefoxGamepadIDPattern = regexp.MustCompile(`^([0-9a-fA-F]{1,4})-([0-9a-fA-F]{1,4})-`)
+)
+
+// gamepadGUID returns an SDL's GUID made from the vendor and the product in the gamepad ID.
+// gamepadGUID returns an empty string if the ID doesn't have them.
+func gamepadGUID(id string) string {
+	m := chromeGamepadIDPattern.FindStringSubmatch(id)
+	if m == nil {
+		m = firefoxGamepadIDPattern.FindStringSubmatch(id)
+	}
+	if m == nil {
+		return ""
+	}
+	vendor, err := strconv.ParseUint(m[1], 16, 16)
+	if err != nil {
+		return ""
+	}
+	product, err := strconv.ParseUint(m[2], 16, 16)
+	if err != nil {
+		return ""
+	}
+	// The bus type is USB (0x03). The 16-bit values are in little endian.
+	return fmt.Sprintf("03000000%02x%02x0000%02x%02x0000000000000000",
+		vendor&0xff, vendor>>8, product&0xff, product>>8)
+}
+
 func (i *Input) updateTouches(t []touch) {
 	i.touches = make([]touch, len(t))
 	copy(i.touches, t)
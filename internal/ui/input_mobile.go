@@ -21,22 +21,40 @@ import (
 )
 
 type Input struct {
-	cursorX  int
-	cursorY  int
-	gamepads [16]gamePad
-	touches  []touch
-	m        sync.RWMutex
+	cursorX      int
+	cursorY      int
+	wheelX       float64
+	wheelY       float64
+	droppedFiles []droppedFile
+	gamepads     [16]gamePad
+	touches      []touch
+	m            sync.RWMutex
 }
 
 func (i *Input) RuneBuffer() []rune {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if o := theInputOverride; o != nil {
+		return o.chars
+	}
 	return nil
 }
 
 func (i *Input) IsKeyPressed(key Key) bool {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if o := theInputOverride; o != nil {
+		return o.isKeyPressed(key)
+	}
 	return false
 }
 
 func (i *Input) IsMouseButtonPressed(key MouseButton) bool {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if o := theInputOverride; o != nil {
+		return o.isMouseButtonPressed(key)
+	}
 	return false
 }
 
@@ -49,5 +67,7 @@ func (i *Input) updateTouches(touches []Touch) {
 		x, y := touches[i].Position()
 		ts[i].x, ts[i].y = x, y
 	}
+	// The touch events of gomobile don't have timestamps.
+	theInputEventQueue.addTouchEvents(i.touches, ts, monotonicTime())
 	i.touches = ts
 }
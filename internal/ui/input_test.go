@@ -0,0 +1,46 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"testing"
+)
+
+func TestGamepadNameDisconnected(t *testing.T) {
+	i := &Input{}
+	i.gamepads[0] = gamePad{
+		valid: true,
+		guid:  "030000005e0400008e02000014010000",
+		name:  "Pad",
+	}
+	if got, want := i.GamepadName(0), "Pad"; got != want {
+		t.Errorf("GamepadName(0): got: %q, want: %q", got, want)
+	}
+	if got, want := i.GamepadSDLID(0), "030000005e0400008e02000014010000"; got != want {
+		t.Errorf("GamepadSDLID(0): got: %q, want: %q", got, want)
+	}
+
+	// A disconnected gamepad keeps its name and GUID internally until another gamepad is connected.
+	i.gamepads[0].valid = false
+	if got, want := i.GamepadName(0), ""; got != want {
+		t.Errorf("GamepadName(0) after disconnecting: got: %q, want: %q", got, want)
+	}
+	if got, want := i.GamepadSDLID(0), ""; got != want {
+		t.Errorf("GamepadSDLID(0) after disconnecting: got: %q, want: %q", got, want)
+	}
+	if got, want := i.GamepadName(16), ""; got != want {
+		t.Errorf("GamepadName(16): got: %q, want: %q", got, want)
+	}
+}
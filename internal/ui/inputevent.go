@@ -0,0 +1,161 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"time"
+
+	"github.com/dave/ebiten/internal/sync"
+)
+
+type InputEventType int
+
+const (
+	InputEventTypeKeyDown InputEventType = iota
+	InputEventTypeKeyUp
+	InputEventTypeChar
+	InputEventTypeMouseButtonDown
+	InputEventTypeMouseButtonUp
+	InputEventTypeMouseMove
+	InputEventTypeWheel
+	InputEventTypeTouchStart
+	InputEventTypeTouchMove
+	InputEventTypeTouchEnd
+)
+
+type InputEvent struct {
+	Type        InputEventType
+	Time        time.Duration
+	Key         Key
+	Char        rune
+	MouseButton MouseButton
+	TouchID     int
+	X           int
+	Y           int
+	WheelX      float64
+	WheelY      float64
+}
+
+// inputEventQueue records the input events in order.
+//
+// The events are recorded only while the queue is enabled,
+// and they are valid only in one frame like the other per-frame input states.
+type inputEventQueue struct {
+	enabled bool
+	events  []InputEvent
+	m       sync.Mutex
+}
+
+var theInputEventQueue = &inputEventQueue{}
+
+var startTime = time.Now()
+
+// monotonicTime returns the current time from an arbitrary origin for the events without timestamps.
+func monotonicTime() time.Duration {
+	return time.Since(startTime)
+}
+
+func (q *inputEventQueue) setEnabled(enabled bool) {
+	q.m.Lock()
+	defer q.m.Unlock()
+	q.enabled = enabled
+	if !enabled {
+		q.events = nil
+	}
+}
+
+func (q *inputEventQueue) isEnabled() bool {
+	q.m.Lock()
+	defer q.m.Unlock()
+	return q.enabled
+}
+
+func (q *inputEventQueue) add(e InputEvent) {
+	q.m.Lock()
+	defer q.m.Unlock()
+	if !q.enabled {
+		return
+	}
+	q.events = append(q.events, e)
+}
+
+func (q *inputEventQueue) get() []InputEvent {
+	q.m.Lock()
+	defer q.m.Unlock()
+	return append(make([]InputEvent, 0, len(q.events)), q.events...)
+}
+
+func (q *inputEventQueue) reset() {
+	q.m.Lock()
+	defer q.m.Unlock()
+	q.events = q.events[:0]
+}
+
+// addTouchEvents adds the events by the difference between the touch states.
+func (q *inputEventQueue) addTouchEvents(prev, current []touch, t time.Duration) {
+	if !q.isEnabled() {
+		return
+	}
+	prevs := map[int]touch{}
+	for _, p := range prev {
+		prevs[p.id] = p
+	}
+	for _, c := range current {
+		e := InputEvent{
+			Time:    t,
+			TouchID: c.id,
+			X:       c.x,
+			Y:       c.y,
+		}
+		p, ok := prevs[c.id]
+		delete(prevs, c.id)
+		switch {
+		case !ok:
+			e.Type = InputEventTypeTouchStart
+		case p.x != c.x || p.y != c.y:
+			e.Type = InputEventTypeTouchMove
+		default:
+			continue
+		}
+		q.add(e)
+	}
+	for _, p := range prev {
+		if _, ok := prevs[p.id]; !ok {
+			continue
+		}
+		q.add(InputEvent{
+			Type:    InputEventTypeTouchEnd,
+			Time:    t,
+			TouchID: p.id,
+			X:       p.x,
+			Y:       p.y,
+		})
+	}
+}
+
+func SetInputEventsEnabled(enabled bool) {
+	theInputEventQueue.setEnabled(enabled)
+}
+
+func (i *Input) InputEvents() []InputEvent {
+	es := theInputEventQueue.get()
+	for n, e := range es {
+		switch e.Type {
+		case InputEventTypeMouseButtonDown, InputEventTypeMouseButtonUp, InputEventTypeMouseMove, InputEventTypeWheel:
+			es[n].X, es[n].Y = adjustCursorPosition(e.X, e.Y)
+		}
+	}
+	return es
+}
@@ -0,0 +1,205 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+// InputState is a snapshot of the input states in one frame.
+type InputState struct {
+	Keys         []Key
+	MouseButtons []MouseButton
+	CursorX      int
+	CursorY      int
+	WheelX       float64
+	WheelY       float64
+	Chars        []rune
+	Gamepads     []GamepadState
+	Touches      []TouchState
+}
+
+type GamepadState struct {
+	ID            int
+	SDLID         string
+	Name          string
+	Standard      bool
+	Axes          []float64
+	ButtonPressed []bool
+	ButtonValues  []float64
+	Hats          []int
+}
+
+type TouchState struct {
+	ID int
+	X  int
+	Y  int
+}
+
+// inputOverride represents the input states used instead of the devices' ones.
+type inputOverride struct {
+	keys         map[Key]struct{}
+	mouseButtons map[MouseButton]struct{}
+	cursorX      int
+	cursorY      int
+	wheelX       float64
+	wheelY       float64
+	chars        []rune
+	gamepads     [16]gamePad
+	touches      []touch
+}
+
+// theInputOverride must be accessed with the lock of currentInput.
+var theInputOverride *inputOverride
+
+func newInputOverride(s *InputState) *inputOverride {
+	o := &inputOverride{
+		keys:         map[Key]struct{}{},
+		mouseButtons: map[MouseButton]struct{}{},
+		cursorX:      s.CursorX,
+		cursorY:      s.CursorY,
+		wheelX:       s.WheelX,
+		wheelY:       s.WheelY,
+		chars:        append([]rune{}, s.Chars...),
+	}
+	for _, k := range s.Keys {
+		o.keys[k] = struct{}{}
+	}
+	for _, b := range s.MouseButtons {
+		o.mouseButtons[b] = struct{}{}
+	}
+	for _, g := range s.Gamepads {
+		if g.ID < 0 || len(o.gamepads) <= g.ID {
+			continue
+		}
+		gp := &o.gamepads[g.ID]
+		gp.valid = true
+		gp.guid = g.SDLID
+		gp.name = g.Name
+		gp.standard = g.Standard
+		gp.axisNum = len(g.Axes)
+		copy(gp.axes[:], g.Axes)
+		gp.buttonNum = len(g.ButtonPressed)
+		copy(gp.buttonPressed[:], g.ButtonPressed)
+		copy(gp.buttonValues[:], g.ButtonValues)
+		gp.hatNum = len(g.Hats)
+		copy(gp.hats[:], g.Hats)
+	}
+	for _, t := range s.Touches {
+		o.touches = append(o.touches, touch{id: t.ID, x: t.X, y: t.Y})
+	}
+	return o
+}
+
+func (o *inputOverride) isKeyPressed(key Key) bool {
+	if ks, ok := modKeyToSideKeys[key]; ok {
+		for _, k := range ks {
+			if _, ok := o.keys[k]; ok {
+				return true
+			}
+		}
+	}
+	_, ok := o.keys[key]
+	return ok
+}
+
+func (o *inputOverride) isMouseButtonPressed(button MouseButton) bool {
+	_, ok := o.mouseButtons[button]
+	return ok
+}
+
+// SetInputStateOverride makes the input functions return the given states instead of the devices' states.
+// If s is nil, the devices' states are used again.
+func (i *Input) SetInputStateOverride(s *InputState) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	if s == nil {
+		theInputOverride = nil
+		return
+	}
+	theInputOverride = newInputOverride(s)
+}
+
+// IsInputStateOverridden reports whether the input states are overridden by SetInputStateOverride.
+func (i *Input) IsInputStateOverridden() bool {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	return theInputOverride != nil
+}
+
+// currentGamepads returns the gamepads to read.
+// currentGamepads must be called with the lock.
+func (i *Input) currentGamepads() *[16]gamePad {
+	if o := theInputOverride; o != nil {
+		return &o.gamepads
+	}
+	return &i.gamepads
+}
+
+// InputState returns the snapshot of the current input states.
+// If the states are overridden, InputState returns the overridden states.
+func (i *Input) InputState() *InputState {
+	s := &InputState{}
+	for k := Key(0); int(k) < len(keyNames); k++ {
+		if i.IsKeyPressed(k) {
+			s.Keys = append(s.Keys, k)
+		}
+	}
+	for _, b := range []MouseButton{MouseButtonLeft, MouseButtonRight, MouseButtonMiddle} {
+		if i.IsMouseButtonPressed(b) {
+			s.MouseButtons = append(s.MouseButtons, b)
+		}
+	}
+	s.Chars = append(s.Chars, i.RuneBuffer()...)
+	et, emulated := i.emulatedTouch()
+
+	i.m.RLock()
+	defer i.m.RUnlock()
+	s.CursorX, s.CursorY = i.cursorX, i.cursorY
+	s.WheelX, s.WheelY = i.wheelX, i.wheelY
+	ts := i.touches
+	if o := theInputOverride; o != nil {
+		s.CursorX, s.CursorY = o.cursorX, o.cursorY
+		s.WheelX, s.WheelY = o.wheelX, o.wheelY
+		ts = o.touches
+	} else {
+		s.CursorX, s.CursorY = adjustCursorPosition(s.CursorX, s.CursorY)
+		if emulated {
+			ts = append(append(make([]touch, 0, len(ts)+1), ts...), et)
+		}
+	}
+	for id, g := range i.currentGamepads() {
+		if !g.valid {
+			continue
+		}
+		s.Gamepads = append(s.Gamepads, GamepadState{
+			ID:            id,
+			SDLID:         g.guid,
+			Name:          g.name,
+			Standard:      g.standard,
+			Axes:          append([]float64{}, g.axes[:minInt(g.axisNum, len(g.axes))]...),
+			ButtonPressed: append([]bool{}, g.buttonPressed[:minInt(g.buttonNum, len(g.buttonPressed))]...),
+			ButtonValues:  append([]float64{}, g.buttonValues[:minInt(g.buttonNum, len(g.buttonValues))]...),
+			Hats:          append([]int{}, g.hats[:minInt(g.hatNum, len(g.hats))]...),
+		})
+	}
+	for _, t := range ts {
+		s.Touches = append(s.Touches, TouchState{ID: t.id, X: t.x, Y: t.y})
+	}
+	return s
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -97,4 +97,195 @@ const (
 	KeySpace
 	KeyTab
 	KeyUp
+	KeyF13
+	KeyF14
+	KeyF15
+	KeyF16
+	KeyF17
+	KeyF18
+	KeyF19
+	KeyF20
+	KeyF21
+	KeyF22
+	KeyF23
+	KeyF24
+	KeyKP0
+	KeyKP1
+	KeyKP2
+	KeyKP3
+	KeyKP4
+	KeyKP5
+	KeyKP6
+	KeyKP7
+	KeyKP8
+	KeyKP9
+	KeyKPAdd
+	KeyKPDecimal
+	KeyKPDivide
+	KeyKPEnter
+	KeyKPEqual
+	KeyKPMultiply
+	KeyKPSubtract
+	KeyLeftAlt
+	KeyLeftControl
+	KeyLeftShift
+	KeyLeftSuper
+	KeyMediaPlayPause
+	KeyMediaStop
+	KeyMediaTrackNext
+	KeyMediaTrackPrevious
+	KeyMenu
+	KeyNumLock
+	KeyPause
+	KeyPrintScreen
+	KeyRightAlt
+	KeyRightControl
+	KeyRightShift
+	KeyRightSuper
+	KeyScrollLock
+	KeyVolumeDown
+	KeyVolumeMute
+	KeyVolumeUp
 )
+
+var keyNames = map[Key]string{
+	Key0:                  "0",
+	Key1:                  "1",
+	Key2:                  "2",
+	Key3:                  "3",
+	Key4:                  "4",
+	Key5:                  "5",
+	Key6:                  "6",
+	Key7:                  "7",
+	Key8:                  "8",
+	Key9:                  "9",
+	KeyA:                  "A",
+	KeyB:                  "B",
+	KeyC:                  "C",
+	KeyD:                  "D",
+	KeyE:                  "E",
+	KeyF:                  "F",
+	KeyG:                  "G",
+	KeyH:                  "H",
+	KeyI:                  "I",
+	KeyJ:                  "J",
+	KeyK:                  "K",
+	KeyL:                  "L",
+	KeyM:                  "M",
+	KeyN:                  "N",
+	KeyO:                  "O",
+	KeyP:                  "P",
+	KeyQ:                  "Q",
+	KeyR:                  "R",
+	KeyS:                  "S",
+	KeyT:                  "T",
+	KeyU:                  "U",
+	KeyV:                  "V",
+	KeyW:                  "W",
+	KeyX:                  "X",
+	KeyY:                  "Y",
+	KeyZ:                  "Z",
+	KeyAlt:                "Alt",
+	KeyApostrophe:         "Apostrophe",
+	KeyBackslash:          "Backslash",
+	KeyBackspace:          "Backspace",
+	KeyCapsLock:           "CapsLock",
+	KeyComma:              "Comma",
+	KeyControl:            "Control",
+	KeyDelete:             "Delete",
+	KeyDown:               "Down",
+	KeyEnd:                "End",
+	KeyEnter:              "Enter",
+	KeyEqual:              "Equal",
+	KeyEscape:             "Escape",
+	KeyF1:                 "F1",
+	KeyF2:                 "F2",
+	KeyF3:                 "F3",
+	KeyF4:                 "F4",
+	KeyF5:                 "F5",
+	KeyF6:                 "F6",
+	KeyF7:                 "F7",
+	KeyF8:                 "F8",
+	KeyF9:                 "F9",
+	KeyF10:                "F10",
+	KeyF11:                "F11",
+	KeyF12:                "F12",
+	KeyGraveAccent:        "GraveAccent",
+	KeyHome:               "Home",
+	KeyInsert:             "Insert",
+	KeyLeft:               "Left",
+	KeyLeftBracket:        "LeftBracket",
+	KeyMinus:              "Minus",
+	KeyPageDown:           "PageDown",
+	KeyPageUp:             "PageUp",
+	KeyPeriod:             "Period",
+	KeyRight:              "Right",
+	KeyRightBracket:       "RightBracket",
+	KeySemicolon:          "Semicolon",
+	KeyShift:              "Shift",
+	KeySlash:              "Slash",
+	KeySpace:              "Space",
+	KeyTab:                "Tab",
+	KeyUp:                 "Up",
+	KeyF13:                "F13",
+	KeyF14:                "F14",
+	KeyF15:                "F15",
+	KeyF16:                "F16",
+	KeyF17:                "F17",
+	KeyF18:                "F18",
+	KeyF19:                "F19",
+	KeyF20:                "F20",
+	KeyF21:                "F21",
+	KeyF22:                "F22",
+	KeyF23:                "F23",
+	KeyF24:                "F24",
+	KeyKP0:                "KP0",
+	KeyKP1:                "KP1",
+	KeyKP2:                "KP2",
+	KeyKP3:                "KP3",
+	KeyKP4:                "KP4",
+	KeyKP5:                "KP5",
+	KeyKP6:                "KP6",
+	KeyKP7:                "KP7",
+	KeyKP8:                "KP8",
+	KeyKP9:                "KP9",
+	KeyKPAdd:              "KPAdd",
+	KeyKPDecimal:          "KPDecimal",
+	KeyKPDivide:           "KPDivide",
+	KeyKPEnter:            "KPEnter",
+	KeyKPEqual:            "KPEqual",
+	KeyKPMultiply:         "KPMultiply",
+	KeyKPSubtract:         "KPSubtract",
+	KeyLeftAlt:            "LeftAlt",
+	KeyLeftControl:        "LeftControl",
+	KeyLeftShift:          "LeftShift",
+	KeyLeftSuper:          "LeftSuper",
+	KeyMediaPlayPause:     "MediaPlayPause",
+	KeyMediaStop:          "MediaStop",
+	KeyMediaTrackNext:     "MediaTrackNext",
+	KeyMediaTrackPrevious: "MediaTrackPrevious",
+	KeyMenu:               "Menu",
+	KeyNumLock:            "NumLock",
+	KeyPause:              "Pause",
+	KeyPrintScreen:        "PrintScreen",
+	KeyRightAlt:           "RightAlt",
+	KeyRightControl:       "RightControl",
+	KeyRightShift:         "RightShift",
+	KeyRightSuper:         "RightSuper",
+	KeyScrollLock:         "ScrollLock",
+	KeyVolumeDown:         "VolumeDown",
+	KeyVolumeMute:         "VolumeMute",
+	KeyVolumeUp:           "VolumeUp",
+}
+
+var modKeyToSideKeys = map[Key][]Key{
+	KeyAlt: {
+		KeyLeftAlt, KeyRightAlt,
+	},
+	KeyControl: {
+		KeyLeftControl, KeyRightControl,
+	},
+	KeyShift: {
+		KeyLeftShift, KeyRightShift,
+	},
+}
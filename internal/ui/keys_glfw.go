@@ -22,7 +22,7 @@
 package ui
 
 import (
-	glfw "github.com/go-gl/glfw/v3.2/glfw"
+	glfw "github.com/go-gl/glfw/v3.3/glfw"
 )
 
 var glfwKeyCodeToKey = map[glfw.Key]Key{
@@ -85,27 +85,62 @@ var glfwKeyCodeToKey = map[glfw.Key]Key{
 	glfw.KeyF10:          KeyF10,
 	glfw.KeyF11:          KeyF11,
 	glfw.KeyF12:          KeyF12,
+	glfw.KeyF13:          KeyF13,
+	glfw.KeyF14:          KeyF14,
+	glfw.KeyF15:          KeyF15,
+	glfw.KeyF16:          KeyF16,
+	glfw.KeyF17:          KeyF17,
+	glfw.KeyF18:          KeyF18,
+	glfw.KeyF19:          KeyF19,
+	glfw.KeyF20:          KeyF20,
+	glfw.KeyF21:          KeyF21,
+	glfw.KeyF22:          KeyF22,
+	glfw.KeyF23:          KeyF23,
+	glfw.KeyF24:          KeyF24,
 	glfw.KeyGraveAccent:  KeyGraveAccent,
 	glfw.KeyHome:         KeyHome,
 	glfw.KeyInsert:       KeyInsert,
+	glfw.KeyKP0:          KeyKP0,
+	glfw.KeyKP1:          KeyKP1,
+	glfw.KeyKP2:          KeyKP2,
+	glfw.KeyKP3:          KeyKP3,
+	glfw.KeyKP4:          KeyKP4,
+	glfw.KeyKP5:          KeyKP5,
+	glfw.KeyKP6:          KeyKP6,
+	glfw.KeyKP7:          KeyKP7,
+	glfw.KeyKP8:          KeyKP8,
+	glfw.KeyKP9:          KeyKP9,
+	glfw.KeyKPAdd:        KeyKPAdd,
+	glfw.KeyKPDecimal:    KeyKPDecimal,
+	glfw.KeyKPDivide:     KeyKPDivide,
+	glfw.KeyKPEnter:      KeyKPEnter,
+	glfw.KeyKPEqual:      KeyKPEqual,
+	glfw.KeyKPMultiply:   KeyKPMultiply,
+	glfw.KeyKPSubtract:   KeyKPSubtract,
 	glfw.KeyLeft:         KeyLeft,
+	glfw.KeyLeftAlt:      KeyLeftAlt,
 	glfw.KeyLeftBracket:  KeyLeftBracket,
+	glfw.KeyLeftControl:  KeyLeftControl,
+	glfw.KeyLeftShift:    KeyLeftShift,
+	glfw.KeyLeftSuper:    KeyLeftSuper,
+	glfw.KeyMenu:         KeyMenu,
 	glfw.KeyMinus:        KeyMinus,
+	glfw.KeyNumLock:      KeyNumLock,
 	glfw.KeyPageDown:     KeyPageDown,
 	glfw.KeyPageUp:       KeyPageUp,
+	glfw.KeyPause:        KeyPause,
 	glfw.KeyPeriod:       KeyPeriod,
+	glfw.KeyPrintScreen:  KeyPrintScreen,
 	glfw.KeyRight:        KeyRight,
+	glfw.KeyRightAlt:     KeyRightAlt,
 	glfw.KeyRightBracket: KeyRightBracket,
+	glfw.KeyRightControl: KeyRightControl,
+	glfw.KeyRightShift:   KeyRightShift,
+	glfw.KeyRightSuper:   KeyRightSuper,
+	glfw.KeyScrollLock:   KeyScrollLock,
 	glfw.KeySemicolon:    KeySemicolon,
 	glfw.KeySlash:        KeySlash,
 	glfw.KeySpace:        KeySpace,
 	glfw.KeyTab:          KeyTab,
 	glfw.KeyUp:           KeyUp,
-
-	glfw.KeyLeftAlt:      KeyAlt,
-	glfw.KeyRightAlt:     KeyAlt,
-	glfw.KeyLeftControl:  KeyControl,
-	glfw.KeyRightControl: KeyControl,
-	glfw.KeyLeftShift:    KeyShift,
-	glfw.KeyRightShift:   KeyShift,
 }
@@ -118,9 +118,45 @@ var keyToCodes = map[Key][]string{
 	KeyF12: {
 		"F12",
 	},
+	KeyF13: {
+		"F13",
+	},
+	KeyF14: {
+		"F14",
+	},
+	KeyF15: {
+		"F15",
+	},
+	KeyF16: {
+		"F16",
+	},
+	KeyF17: {
+		"F17",
+	},
+	KeyF18: {
+		"F18",
+	},
+	KeyF19: {
+		"F19",
+	},
 	KeyF2: {
 		"F2",
 	},
+	KeyF20: {
+		"F20",
+	},
+	KeyF21: {
+		"F21",
+	},
+	KeyF22: {
+		"F22",
+	},
+	KeyF23: {
+		"F23",
+	},
+	KeyF24: {
+		"F24",
+	},
 	KeyF3: {
 		"F3",
 	},
@@ -166,24 +202,105 @@ var keyToCodes = map[Key][]string{
 	KeyK: {
 		"KeyK",
 	},
+	KeyKP0: {
+		"Numpad0",
+	},
+	KeyKP1: {
+		"Numpad1",
+	},
+	KeyKP2: {
+		"Numpad2",
+	},
+	KeyKP3: {
+		"Numpad3",
+	},
+	KeyKP4: {
+		"Numpad4",
+	},
+	KeyKP5: {
+		"Numpad5",
+	},
+	KeyKP6: {
+		"Numpad6",
+	},
+	KeyKP7: {
+		"Numpad7",
+	},
+	KeyKP8: {
+		"Numpad8",
+	},
+	KeyKP9: {
+		"Numpad9",
+	},
+	KeyKPAdd: {
+		"NumpadAdd",
+	},
+	KeyKPDecimal: {
+		"NumpadDecimal",
+	},
+	KeyKPDivide: {
+		"NumpadDivide",
+	},
+	KeyKPEnter: {
+		"NumpadEnter",
+	},
+	KeyKPEqual: {
+		"NumpadEqual",
+	},
+	KeyKPMultiply: {
+		"NumpadMultiply",
+	},
+	KeyKPSubtract: {
+		"NumpadSubtract",
+	},
 	KeyL: {
 		"KeyL",
 	},
 	KeyLeft: {
 		"ArrowLeft",
 	},
+	KeyLeftAlt: {
+		"AltLeft",
+	},
 	KeyLeftBracket: {
 		"BracketLeft",
 	},
+	KeyLeftControl: {
+		"ControlLeft",
+	},
+	KeyLeftShift: {
+		"ShiftLeft",
+	},
+	KeyLeftSuper: {
+		"MetaLeft", "OSLeft",
+	},
 	KeyM: {
 		"KeyM",
 	},
+	KeyMediaPlayPause: {
+		"MediaPlayPause",
+	},
+	KeyMediaStop: {
+		"MediaStop",
+	},
+	KeyMediaTrackNext: {
+		"MediaTrackNext",
+	},
+	KeyMediaTrackPrevious: {
+		"MediaTrackPrevious",
+	},
+	KeyMenu: {
+		"ContextMenu",
+	},
 	KeyMinus: {
 		"Minus",
 	},
 	KeyN: {
 		"KeyN",
 	},
+	KeyNumLock: {
+		"NumLock",
+	},
 	KeyO: {
 		"KeyO",
 	},
@@ -196,9 +313,15 @@ var keyToCodes = map[Key][]string{
 	KeyPageUp: {
 		"PageUp",
 	},
+	KeyPause: {
+		"Pause",
+	},
 	KeyPeriod: {
 		"Period",
 	},
+	KeyPrintScreen: {
+		"PrintScreen",
+	},
 	KeyQ: {
 		"KeyQ",
 	},
@@ -208,12 +331,27 @@ var keyToCodes = map[Key][]string{
 	KeyRight: {
 		"ArrowRight",
 	},
+	KeyRightAlt: {
+		"AltRight",
+	},
 	KeyRightBracket: {
 		"BracketRight",
 	},
+	KeyRightControl: {
+		"ControlRight",
+	},
+	KeyRightShift: {
+		"ShiftRight",
+	},
+	KeyRightSuper: {
+		"MetaRight", "OSRight",
+	},
 	KeyS: {
 		"KeyS",
 	},
+	KeyScrollLock: {
+		"ScrollLock",
+	},
 	KeySemicolon: {
 		"Semicolon",
 	},
@@ -241,6 +379,15 @@ var keyToCodes = map[Key][]string{
 	KeyV: {
 		"KeyV",
 	},
+	KeyVolumeDown: {
+		"AudioVolumeDown", "VolumeDown",
+	},
+	KeyVolumeMute: {
+		"AudioVolumeMute", "VolumeMute",
+	},
+	KeyVolumeUp: {
+		"AudioVolumeUp", "VolumeUp",
+	},
 	KeyW: {
 		"KeyW",
 	},
@@ -262,6 +409,7 @@ var keyCodeToKeyEdge = map[int]Key{
 	16:  KeyShift,
 	17:  KeyControl,
 	18:  KeyAlt,
+	19:  KeyPause,
 	20:  KeyCapsLock,
 	27:  KeyEscape,
 	32:  KeySpace,
@@ -273,6 +421,7 @@ var keyCodeToKeyEdge = map[int]Key{
 	38:  KeyUp,
 	39:  KeyRight,
 	40:  KeyDown,
+	44:  KeyPrintScreen,
 	45:  KeyInsert,
 	46:  KeyDelete,
 	48:  Key0,
@@ -311,6 +460,24 @@ var keyCodeToKeyEdge = map[int]Key{
 	88:  KeyX,
 	89:  KeyY,
 	90:  KeyZ,
+	91:  KeyLeftSuper,
+	92:  KeyRightSuper,
+	93:  KeyMenu,
+	96:  KeyKP0,
+	97:  KeyKP1,
+	98:  KeyKP2,
+	99:  KeyKP3,
+	100: KeyKP4,
+	101: KeyKP5,
+	102: KeyKP6,
+	103: KeyKP7,
+	104: KeyKP8,
+	105: KeyKP9,
+	106: KeyKPMultiply,
+	107: KeyKPAdd,
+	109: KeyKPSubtract,
+	110: KeyKPDecimal,
+	111: KeyKPDivide,
 	112: KeyF1,
 	113: KeyF2,
 	114: KeyF3,
@@ -323,6 +490,27 @@ var keyCodeToKeyEdge = map[int]Key{
 	121: KeyF10,
 	122: KeyF11,
 	123: KeyF12,
+	124: KeyF13,
+	125: KeyF14,
+	126: KeyF15,
+	127: KeyF16,
+	128: KeyF17,
+	129: KeyF18,
+	130: KeyF19,
+	131: KeyF20,
+	132: KeyF21,
+	133: KeyF22,
+	134: KeyF23,
+	135: KeyF24,
+	144: KeyNumLock,
+	145: KeyScrollLock,
+	173: KeyVolumeMute,
+	174: KeyVolumeDown,
+	175: KeyVolumeUp,
+	176: KeyMediaTrackNext,
+	177: KeyMediaTrackPrevious,
+	178: KeyMediaStop,
+	179: KeyMediaPlayPause,
 	186: KeySemicolon,
 	187: KeyEqual,
 	188: KeyComma,
@@ -0,0 +1,51 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+// EmulatedTouchID is the ID of the touch emulated by the mouse.
+const EmulatedTouchID = -1
+
+// isTouchEmulationEnabled must be accessed with the lock of currentInput.
+var isTouchEmulationEnabled bool
+
+// SetTouchEmulationEnabled sets whether the left mouse button emulates a touch.
+func (i *Input) SetTouchEmulationEnabled(enabled bool) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	isTouchEmulationEnabled = enabled
+}
+
+// IsTouchEmulationEnabled reports whether the left mouse button emulates a touch.
+func (i *Input) IsTouchEmulationEnabled() bool {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	return isTouchEmulationEnabled
+}
+
+// emulatedTouch returns the touch emulated by the mouse if any.
+// emulatedTouch must be called without the lock.
+func (i *Input) emulatedTouch() (touch, bool) {
+	i.m.RLock()
+	enabled := isTouchEmulationEnabled && theInputOverride == nil
+	i.m.RUnlock()
+	if !enabled {
+		return touch{}, false
+	}
+	if !i.IsMouseButtonPressed(MouseButtonLeft) {
+		return touch{}, false
+	}
+	x, y := i.CursorPosition()
+	return touch{id: EmulatedTouchID, x: x, y: y}, true
+}
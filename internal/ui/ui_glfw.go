@@ -23,10 +23,11 @@ import (
 	"image"
 	"math"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/go-gl/glfw/v3.3/glfw"
 
 	"github.com/dave/ebiten/internal/devicescale"
 	"github.com/dave/ebiten/internal/opengl"
@@ -361,6 +362,87 @@ func SetCursorVisible(visible bool) {
 	})
 }
 
+func ClipboardText() string {
+	u := currentUI
+	if !u.isRunning() {
+		return theMemoryClipboard.get()
+	}
+	t := ""
+	_ = u.runOnMainThread(func() error {
+		// GetClipboardString returns an empty string when the clipboard is empty or
+		// its content can't be converted to a string.
+		t = u.window.GetClipboardString()
+		return nil
+	})
+	return t
+}
+
+func SetClipboardText(text string) {
+	u := currentUI
+	if !u.isRunning() {
+		theMemoryClipboard.set(text)
+		return
+	}
+	_ = u.runOnMainThread(func() error {
+		u.window.SetClipboardString(text)
+		return nil
+	})
+}
+
+func IMEComposition() (string, int) {
+	u := currentUI
+	if !u.isRunning() {
+		return "", 0
+	}
+	t, c := "", 0
+	_ = u.runOnMainThread(func() error {
+		t, c = imeComposition()
+		return nil
+	})
+	return t, c
+}
+
+// SetIMEEnabled does nothing on desktops: the IME is always available as the OS provides.
+func SetIMEEnabled(enabled bool) {
+}
+
+func SetIMECandidateWindowPosition(x, y int) {
+	u := currentUI
+	if !u.isRunning() {
+		return
+	}
+	ox, oy := ScreenOffset()
+	_ = u.runOnMainThread(func() error {
+		s := u.actualScreenScale()
+		setIMECandidateWindowPosition(int(float64(x)*s+ox), int(float64(y)*s+oy))
+		return nil
+	})
+}
+
+func KeyName(key Key) string {
+	u := currentUI
+	// The names of numpad keys like "1" are confusing with the other keys.
+	if !u.isRunning() || strings.HasPrefix(keyNames[key], "KP") {
+		return keyNames[key]
+	}
+	n := ""
+	_ = u.runOnMainThread(func() error {
+		for gk, k := range glfwKeyCodeToKey {
+			if k != key {
+				continue
+			}
+			// GetKeyName returns an empty string for non-printable keys.
+			n = glfw.GetKeyName(gk, 0)
+			break
+		}
+		return nil
+	})
+	if n == "" {
+		return keyNames[key]
+	}
+	return strings.ToUpper(n)
+}
+
 func Run(width, height int, scale float64, title string, g GraphicsContext) error {
 	<-currentUIInitialized
 
@@ -475,6 +557,7 @@ func (u *userInterface) update(g GraphicsContext) error {
 	})
 	if err := g.Update(func() {
 		currentInput.runeBuffer = currentInput.runeBuffer[:0]
+		currentInput.resetForFrame()
 		// The offscreens must be updated every frame (#490).
 		u.updateGraphicsContext(g)
 	}); err != nil {
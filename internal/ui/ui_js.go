@@ -19,16 +19,31 @@ package ui
 import (
 	"image"
 	"strconv"
+	"strings"
+	"time"
 	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/gopherjs/gopherjs/js"
 
 	"github.com/dave/ebiten/internal/devicescale"
 	"github.com/dave/ebiten/internal/opengl"
+	"github.com/dave/ebiten/internal/web"
 )
 
 var canvas *js.Object
 
+// imeInput is a hidden input element to receive compositions of the IME.
+var imeInput *js.Object
+
+// clipboardReadTimeout is the maximum duration to wait for reading the clipboard.
+// Reading might wait for the user's answer to the permission prompt, which would stop the game.
+const clipboardReadTimeout = 100 * time.Millisecond
+
+// keyboardLayoutMap maps codes of KeyboardEvent to the characters on the current keyboard layout.
+var keyboardLayoutMap = map[string]string{}
+
 type userInterface struct {
 	width                int
 	height               int
@@ -38,6 +53,11 @@ type userInterface struct {
 
 	sizeChanged bool
 	windowFocus bool
+
+	imeEnabled   bool
+	imeComposing bool
+	imeText      string
+	imeCaret     int
 }
 
 var currentUI = &userInterface{
@@ -98,6 +118,143 @@ func SetWindowIcon(iconImages []image.Image) {
 	// Do nothing
 }
 
+func ClipboardText() string {
+	if web.IsNodeJS() {
+		return theMemoryClipboard.get()
+	}
+	c := js.Global.Get("navigator").Get("clipboard")
+	if c == js.Undefined || c.Get("readText") == js.Undefined {
+		return theMemoryClipboard.get()
+	}
+	// readText is asynchronous. Wait for the promise to be settled.
+	// If reading is rejected e.g. due to the permission or is not settled in time,
+	// use the text known by the last paste event or SetClipboardText instead.
+	// A text read after the timeout is still available for the next call.
+	ch := make(chan string, 1)
+	c.Call("readText").Call("then", func(text string) {
+		theMemoryClipboard.set(text)
+		ch <- text
+	}, func(err *js.Object) {
+		ch <- theMemoryClipboard.get()
+	})
+	select {
+	case text := <-ch:
+		return text
+	case <-time.After(clipboardReadTimeout):
+		return theMemoryClipboard.get()
+	}
+}
+
+func SetClipboardText(text string) {
+	theMemoryClipboard.set(text)
+	if web.IsNodeJS() {
+		return
+	}
+	c := js.Global.Get("navigator").Get("clipboard")
+	if c == js.Undefined || c.Get("writeText") == js.Undefined {
+		return
+	}
+	// Writing is done asynchronously. An error (e.g. no permission) is ignored
+	// and the in-memory text is still available.
+	c.Call("writeText", text).Call("catch", func(err *js.Object) {})
+}
+
+func KeyName(key Key) string {
+	// The names of numpad keys like "1" are confusing with the other keys.
+	if strings.HasPrefix(keyNames[key], "KP") {
+		return keyNames[key]
+	}
+	for _, c := range keyToCodes[key] {
+		if n, ok := keyboardLayoutMap[c]; ok && n != "" {
+			return strings.ToUpper(n)
+		}
+	}
+	return keyNames[key]
+}
+
+func IMEComposition() (string, int) {
+	return currentUI.imeText, currentUI.imeCaret
+}
+
+func SetIMEEnabled(enabled bool) {
+	u := currentUI
+	if u.imeEnabled == enabled {
+		return
+	}
+	u.imeEnabled = enabled
+	if imeInput == nil {
+		return
+	}
+	if !enabled {
+		u.imeComposing = false
+		u.imeText = ""
+		u.imeCaret = 0
+		imeInput.Set("value", "")
+	}
+	focus()
+}
+
+func SetIMECandidateWindowPosition(x, y int) {
+	if imeInput == nil {
+		return
+	}
+	// The candidate window is shown around the hidden input element.
+	scale := currentUI.getScale()
+	rect := canvas.Call("getBoundingClientRect")
+	left := rect.Get("left").Float() + float64(x)*scale
+	top := rect.Get("top").Float() + float64(y)*scale
+	style := imeInput.Get("style")
+	style.Set("left", strconv.Itoa(int(left))+"px")
+	style.Set("top", strconv.Itoa(int(top))+"px")
+}
+
+// focus focuses the element to receive the keyboard events.
+func focus() {
+	if currentUI.imeEnabled && imeInput != nil {
+		imeInput.Call("focus")
+		return
+	}
+	canvas.Call("focus")
+}
+
+// updateIMEComposition updates the composition by the hidden input element.
+func updateIMEComposition() {
+	u := currentUI
+	v := imeInput.Get("value").String()
+	u.imeText = v
+
+	// selectionEnd is counted in UTF-16.
+	e := imeInput.Get("selectionEnd")
+	if e == nil || e == js.Undefined {
+		u.imeCaret = utf8.RuneCountInString(v)
+		return
+	}
+	s := utf16.Encode([]rune(v))
+	n := e.Int()
+	if n > len(s) {
+		n = len(s)
+	}
+	u.imeCaret = len(utf16.Decode(s[:n]))
+}
+
+// updateKeyboardLayoutMap records the character of the key by a keydown event.
+// This is needed when the Keyboard API is not available.
+func updateKeyboardLayoutMap(code string, e *js.Object) {
+	if e.Get("shiftKey").Bool() || e.Get("ctrlKey").Bool() || e.Get("altKey").Bool() || e.Get("metaKey").Bool() {
+		return
+	}
+	k := e.Get("key")
+	if k == js.Undefined {
+		return
+	}
+	// key is a name like "Enter" or "Dead" for keys without a printable character.
+	ks := k.String()
+	if utf8.RuneCountInString(ks) != 1 {
+		return
+	}
+	keyboardLayoutMap[code] = ks
+}
+
 func (u *userInterface) getScale() float64 {
 	if !u.fullscreen {
 		return u.scale
@@ -142,6 +299,7 @@ func (u *userInterface) update(g GraphicsContext) error {
 	u.updateGraphicsContext(g)
 	if err := g.Update(func() {
 		currentInput.runeBuffer = nil
+		currentInput.resetForFrame()
 		// The offscreens must be updated every frame (#490).
 		u.updateGraphicsContext(g)
 	}); err != nil {
@@ -239,7 +397,7 @@ func initialize() error {
 	// TODO: This is OK as long as the game is in an independent iframe.
 	// What if the canvas is embedded in a HTML directly?
 	doc.Get("body").Call("addEventListener", "click", func() {
-		canvas.Call("focus")
+		focus()
 	})
 
 	canvasStyle := canvas.Get("style")
@@ -249,48 +407,63 @@ func initialize() error {
 	canvas.Call("setAttribute", "tabindex", 1)
 	canvas.Get("style").Set("outline", "none")
 
+	// The IME works only with an editable element.
+	// The input element is transparent so that the game can render the composition by itself.
+	imeInput = doc.Call("createElement", "input")
+	imeInput.Call("setAttribute", "autocomplete", "off")
+	imeStyle := imeInput.Get("style")
+	imeStyle.Set("position", "absolute")
+	imeStyle.Set("left", "0")
+	imeStyle.Set("top", "0")
+	imeStyle.Set("width", "1px")
+	imeStyle.Set("height", "1px")
+	imeStyle.Set("padding", "0")
+	imeStyle.Set("border", "none")
+	imeStyle.Set("opacity", "0")
+	imeStyle.Set("pointerEvents", "none")
+	doc.Get("body").Call("appendChild", imeInput)
+
 	// Keyboard
-	canvas.Call("addEventListener", "keydown", func(e *js.Object) {
-		c := e.Get("code")
-		if c == js.Undefined {
-			code := e.Get("keyCode").Int()
-			if keyCodeToKeyEdge[code] == KeyUp ||
-				keyCodeToKeyEdge[code] == KeyDown ||
-				keyCodeToKeyEdge[code] == KeyLeft ||
-				keyCodeToKeyEdge[code] == KeyRight ||
-				keyCodeToKeyEdge[code] == KeyBackspace ||
-				keyCodeToKeyEdge[code] == KeyTab {
-				e.Call("preventDefault")
-			}
-			currentInput.keyDownEdge(code)
-			return
-		}
-		cs := c.String()
-		if cs == keyToCodes[KeyUp][0] ||
-			cs == keyToCodes[KeyDown][0] ||
-			cs == keyToCodes[KeyLeft][0] ||
-			cs == keyToCodes[KeyRight][0] ||
-			cs == keyToCodes[KeyBackspace][0] ||
-			cs == keyToCodes[KeyTab][0] {
-			e.Call("preventDefault")
-		}
-		currentInput.keyDown(cs)
+	if kb := js.Global.Get("navigator").Get("keyboard"); kb != js.Undefined && kb.Get("getLayoutMap") != js.Undefined {
+		// The Keyboard API is available only on some browsers like Chrome.
+		kb.Call("getLayoutMap").Call("then", func(m *js.Object) {
+			m.Call("forEach", func(key, code string) {
+				keyboardLayoutMap[code] = key
+			})
+		}).Call("catch", func(err *js.Object) {})
+	}
+	// The hidden input element receives the keyboard events instead of the canvas while the IME is enabled.
+	for _, t := range []*js.Object{canvas, imeInput} {
+		t.Call("addEventListener", "keydown", onKeyDown)
+		t.Call("addEventListener", "keypress", onKeyPress)
+		t.Call("addEventListener", "keyup", onKeyUp)
+	}
+
+	// IME
+	imeInput.Call("addEventListener", "compositionstart", func(e *js.Object) {
+		currentUI.imeComposing = true
 	})
-	canvas.Call("addEventListener", "keypress", func(e *js.Object) {
-		e.Call("preventDefault")
-		if r := rune(e.Get("charCode").Int()); unicode.IsPrint(r) {
-			currentInput.runeBuffer = append(currentInput.runeBuffer, r)
+	imeInput.Call("addEventListener", "input", func(e *js.Object) {
+		if !currentUI.imeComposing {
+			imeInput.Set("value", "")
+			return
 		}
+		updateIMEComposition()
 	})
-	canvas.Call("addEventListener", "keyup", func(e *js.Object) {
-		e.Call("preventDefault")
-		if e.Get("code") == js.Undefined {
-			// Assume that UA is Edge.
-			code := e.Get("keyCode").Int()
-			currentInput.keyUpEdge(code)
+	imeInput.Call("addEventListener", "compositionend", func(e *js.Object) {
+		u := currentUI
+		u.imeComposing = false
+		u.imeText = ""
+		u.imeCaret = 0
+		imeInput.Set("value", "")
+		if d := e.Get("data"); d != js.Undefined && d != nil {
+			for _, r := range d.String() {
+				if unicode.IsPrint(r) {
+					currentInput.runeBuffer = append(currentInput.runeBuffer, r)
+					addInputEvent(e, InputEvent{Type: InputEventTypeChar, Char: r})
+				}
+			}
 		}
-		code := e.Get("code").String()
-		currentInput.keyUp(code)
 	})
 
 	// Mouse
@@ -299,16 +472,29 @@ func initialize() error {
 		button := e.Get("button").Int()
 		currentInput.mouseDown(button)
 		setMouseCursorFromEvent(e)
+		if b, ok := codeToMouseButton[button]; ok {
+			addInputEvent(e, InputEvent{Type: InputEventTypeMouseButtonDown, MouseButton: b})
+		}
 	})
 	canvas.Call("addEventListener", "mouseup", func(e *js.Object) {
 		e.Call("preventDefault")
 		button := e.Get("button").Int()
 		currentInput.mouseUp(button)
 		setMouseCursorFromEvent(e)
+		if b, ok := codeToMouseButton[button]; ok {
+			addInputEvent(e, InputEvent{Type: InputEventTypeMouseButtonUp, MouseButton: b})
+		}
 	})
 	canvas.Call("addEventListener", "mousemove", func(e *js.Object) {
 		e.Call("preventDefault")
 		setMouseCursorFromEvent(e)
+		addInputEvent(e, InputEvent{Type: InputEventTypeMouseMove})
+	})
+	canvas.Call("addEventListener", "wheel", func(e *js.Object) {
+		e.Call("preventDefault")
+		x, y := wheelEventToOffsets(e)
+		currentInput.addWheel(x, y)
+		addInputEvent(e, InputEvent{Type: InputEventTypeWheel, WheelX: x, WheelY: y})
 	})
 	canvas.Call("addEventListener", "contextmenu", func(e *js.Object) {
 		e.Call("preventDefault")
@@ -317,15 +503,15 @@ func initialize() error {
 	// Touch
 	canvas.Call("addEventListener", "touchstart", func(e *js.Object) {
 		e.Call("preventDefault")
-		currentInput.updateTouches(touchEventToTouches(e))
+		updateTouchesFromEvent(e)
 	})
 	canvas.Call("addEventListener", "touchend", func(e *js.Object) {
 		e.Call("preventDefault")
-		currentInput.updateTouches(touchEventToTouches(e))
+		updateTouchesFromEvent(e)
 	})
 	canvas.Call("addEventListener", "touchmove", func(e *js.Object) {
 		e.Call("preventDefault")
-		currentInput.updateTouches(touchEventToTouches(e))
+		updateTouchesFromEvent(e)
 	})
 
 	// Gamepad
@@ -333,6 +519,39 @@ func initialize() error {
 		// Do nothing.
 	})
 
+	// Drag and drop
+	canvas.Call("addEventListener", "dragover", func(e *js.Object) {
+		// Dropping is not allowed unless the default behavior is prevented.
+		e.Call("preventDefault")
+	})
+	canvas.Call("addEventListener", "drop", func(e *js.Object) {
+		e.Call("preventDefault")
+		x, y := cursorPositionFromEvent(e)
+		files := e.Get("dataTransfer").Get("files")
+		for i := 0; i < files.Get("length").Int(); i++ {
+			f := files.Call("item", i)
+			name := f.Get("name").String()
+			// The content is read asynchronously. The file is reported after the content is read.
+			r := js.Global.Get("FileReader").New()
+			r.Call("addEventListener", "load", func() {
+				data := js.Global.Get("Uint8Array").New(r.Get("result")).Interface().([]uint8)
+				currentInput.addDroppedFile(name, x, y, data)
+			})
+			r.Call("readAsArrayBuffer", f)
+		}
+	})
+
+	// Clipboard
+	// The canvas doesn't receive paste events since it is not editable.
+	// Listen to the document, which receives the events targeted to the hidden input element for the IME too.
+	doc.Call("addEventListener", "paste", func(e *js.Object) {
+		d := e.Get("clipboardData")
+		if d == js.Undefined || d == nil {
+			return
+		}
+		theMemoryClipboard.set(d.Call("getData", "text/plain").String())
+	})
+
 	canvas.Call("addEventListener", "webglcontextlost", func(e *js.Object) {
 		e.Call("preventDefault")
 	})
@@ -343,13 +562,141 @@ func initialize() error {
 	return nil
 }
 
-func setMouseCursorFromEvent(e *js.Object) {
+func onKeyDown(e *js.Object) {
+	if e.Get("isComposing").Bool() || e.Get("keyCode").Int() == 229 {
+		// The event is processed by the IME.
+		return
+	}
+	c := e.Get("code")
+	if c == js.Undefined {
+		code := e.Get("keyCode").Int()
+		if keyCodeToKeyEdge[code] == KeyUp ||
+			keyCodeToKeyEdge[code] == KeyDown ||
+			keyCodeToKeyEdge[code] == KeyLeft ||
+			keyCodeToKeyEdge[code] == KeyRight ||
+			keyCodeToKeyEdge[code] == KeyBackspace ||
+			keyCodeToKeyEdge[code] == KeyTab {
+			e.Call("preventDefault")
+		}
+		currentInput.keyDownEdge(code)
+		if k, ok := keyCodeToKeyEdge[code]; ok && !e.Get("repeat").Bool() {
+			addInputEvent(e, InputEvent{Type: InputEventTypeKeyDown, Key: k})
+		}
+		return
+	}
+	cs := c.String()
+	if cs == keyToCodes[KeyUp][0] ||
+		cs == keyToCodes[KeyDown][0] ||
+		cs == keyToCodes[KeyLeft][0] ||
+		cs == keyToCodes[KeyRight][0] ||
+		cs == keyToCodes[KeyBackspace][0] ||
+		cs == keyToCodes[KeyTab][0] {
+		e.Call("preventDefault")
+	}
+	updateKeyboardLayoutMap(cs, e)
+	currentInput.keyDown(cs)
+	if k, ok := codeToKey[cs]; ok && !e.Get("repeat").Bool() {
+		addInputEvent(e, InputEvent{Type: InputEventTypeKeyDown, Key: k})
+	}
+}
+
+func onKeyPress(e *js.Object) {
+	e.Call("preventDefault")
+	if r := rune(e.Get("charCode").Int()); unicode.IsPrint(r) {
+		currentInput.runeBuffer = append(currentInput.runeBuffer, r)
+		addInputEvent(e, InputEvent{Type: InputEventTypeChar, Char: r})
+	}
+}
+
+func onKeyUp(e *js.Object) {
+	e.Call("preventDefault")
+	if e.Get("code") == js.Undefined {
+		// Assume that UA is Edge.
+		code := e.Get("keyCode").Int()
+		currentInput.keyUpEdge(code)
+		if k, ok := keyCodeToKeyEdge[code]; ok {
+			addInputEvent(e, InputEvent{Type: InputEventTypeKeyUp, Key: k})
+		}
+	}
+	code := e.Get("code").String()
+	currentInput.keyUp(code)
+	if k, ok := codeToKey[code]; ok {
+		addInputEvent(e, InputEvent{Type: InputEventTypeKeyUp, Key: k})
+	}
+}
+
+// codeToKey maps codes of KeyboardEvent to the keys.
+// The modifier keys are mapped to the keys with the left/right distinction.
+var codeToKey = func() map[string]Key {
+	m := map[string]Key{}
+	for k, cs := range keyToCodes {
+		if _, ok := modKeyToSideKeys[k]; ok {
+			continue
+		}
+		for _, c := range cs {
+			m[c] = k
+		}
+	}
+	return m
+}()
+
+// eventTime returns the timestamp of the DOM event.
+func eventTime(e *js.Object) time.Duration {
+	t := e.Get("timeStamp")
+	if t == js.Undefined {
+		return monotonicTime()
+	}
+	return time.Duration(t.Float() * float64(time.Millisecond))
+}
+
+// addInputEvent adds the input event with the time of the DOM event to the queue.
+// The position of the event is the cursor position.
+func addInputEvent(e *js.Object, ev InputEvent) {
+	ev.Time = eventTime(e)
+	switch ev.Type {
+	case InputEventTypeMouseButtonDown, InputEventTypeMouseButtonUp, InputEventTypeMouseMove, InputEventTypeWheel:
+		ev.X, ev.Y = currentInput.cursorX, currentInput.cursorY
+	}
+	theInputEventQueue.add(ev)
+}
+
+func updateTouchesFromEvent(e *js.Object) {
+	t := touchEventToTouches(e)
+	theInputEventQueue.addTouchEvents(currentInput.touches, t, eventTime(e))
+	currentInput.updateTouches(t)
+}
+
+// wheelEventToOffsets converts the deltas of a WheelEvent into the same units as GLFW's scroll offsets:
+// one notch of a typical wheel is 1, and a positive y value means scrolling up.
+func wheelEventToOffsets(e *js.Object) (xoff, yoff float64) {
+	x := e.Get("deltaX").Float()
+	y := e.Get("deltaY").Float()
+	switch e.Get("deltaMode").Int() {
+	case 0: // DOM_DELTA_PIXEL
+		// Browsers report around 100 pixels for one notch.
+		x /= 100
+		y /= 100
+	case 1: // DOM_DELTA_LINE
+		// Browsers report around 3 lines for one notch.
+		x /= 3
+		y /= 3
+	case 2: // DOM_DELTA_PAGE
+		// Treat one page as one notch.
+	}
+	return -x, -y
+}
+
+func cursorPositionFromEvent(e *js.Object) (x, y int) {
 	scale := currentUI.getScale()
 	rect := canvas.Call("getBoundingClientRect")
-	x, y := e.Get("clientX").Int(), e.Get("clientY").Int()
+	x, y = e.Get("clientX").Int(), e.Get("clientY").Int()
 	x -= rect.Get("left").Int()
 	y -= rect.Get("top").Int()
-	currentInput.setMouseCursor(int(float64(x)/scale), int(float64(y)/scale))
+	return int(float64(x) / scale), int(float64(y) / scale)
+}
+
+func setMouseCursorFromEvent(e *js.Object) {
+	currentInput.setMouseCursor(cursorPositionFromEvent(e))
 }
 
 func RunMainThreadLoop(ch <-chan error) error {
@@ -361,7 +708,7 @@ func Run(width, height int, scale float64, title string, g GraphicsContext) erro
 	doc := js.Global.Get("document")
 	doc.Set("title", title)
 	u.setScreenSize(width, height, scale, u.fullscreen)
-	canvas.Call("focus")
+	focus()
 	if err := opengl.Init(); err != nil {
 		return err
 	}
@@ -112,6 +112,7 @@ func (u *userInterface) update(g GraphicsContext) error {
 	u.updateGraphicsContext(g)
 
 	if err := g.Update(func() {
+		currentInput.resetForFrame()
 		u.updateGraphicsContext(g)
 	}); err != nil {
 		return err
@@ -194,6 +195,28 @@ func SetWindowIcon(iconImages []image.Image) {
 	// Do nothing
 }
 
+func ClipboardText() string {
+	return theMemoryClipboard.get()
+}
+
+func SetClipboardText(text string) {
+	theMemoryClipboard.set(text)
+}
+
+func IMEComposition() (string, int) {
+	return "", 0
+}
+
+func SetIMEEnabled(enabled bool) {
+}
+
+func SetIMECandidateWindowPosition(x, y int) {
+}
+
+func KeyName(key Key) string {
+	return keyNames[key]
+}
+
 func UpdateTouches(touches []Touch) {
 	currentInput.updateTouches(touches)
 }
@@ -17,93 +17,694 @@
 package ebiten
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/dave/ebiten/internal/ui"
 )
 
 // A Key represents a keyboard key.
 // These keys represent pysical keys of US keyboard.
 // For example, KeyQ represents Q key on US keyboards and ' (quote) key on Dvorak keyboards.
+// Use KeyName to get the name of a key on the user's keyboard layout.
+//
+// KeyAlt, KeyControl and KeyShift represent either of the left and right keys.
+//
+// The media keys like KeyMediaPlayPause and KeyVolumeUp are available only on browsers.
 type Key int
 
 // Keys
 const (
-	Key0            Key = Key(ui.Key0)
-	Key1            Key = Key(ui.Key1)
-	Key2            Key = Key(ui.Key2)
-	Key3            Key = Key(ui.Key3)
-	Key4            Key = Key(ui.Key4)
-	Key5            Key = Key(ui.Key5)
-	Key6            Key = Key(ui.Key6)
-	Key7            Key = Key(ui.Key7)
-	Key8            Key = Key(ui.Key8)
-	Key9            Key = Key(ui.Key9)
-	KeyA            Key = Key(ui.KeyA)
-	KeyB            Key = Key(ui.KeyB)
-	KeyC            Key = Key(ui.KeyC)
-	KeyD            Key = Key(ui.KeyD)
-	KeyE            Key = Key(ui.KeyE)
-	KeyF            Key = Key(ui.KeyF)
-	KeyG            Key = Key(ui.KeyG)
-	KeyH            Key = Key(ui.KeyH)
-	KeyI            Key = Key(ui.KeyI)
-	KeyJ            Key = Key(ui.KeyJ)
-	KeyK            Key = Key(ui.KeyK)
-	KeyL            Key = Key(ui.KeyL)
-	KeyM            Key = Key(ui.KeyM)
-	KeyN            Key = Key(ui.KeyN)
-	KeyO            Key = Key(ui.KeyO)
-	KeyP            Key = Key(ui.KeyP)
-	KeyQ            Key = Key(ui.KeyQ)
-	KeyR            Key = Key(ui.KeyR)
-	KeyS            Key = Key(ui.KeyS)
-	KeyT            Key = Key(ui.KeyT)
-	KeyU            Key = Key(ui.KeyU)
-	KeyV            Key = Key(ui.KeyV)
-	KeyW            Key = Key(ui.KeyW)
-	KeyX            Key = Key(ui.KeyX)
-	KeyY            Key = Key(ui.KeyY)
-	KeyZ            Key = Key(ui.KeyZ)
-	KeyAlt          Key = Key(ui.KeyAlt)
-	KeyApostrophe   Key = Key(ui.KeyApostrophe)
-	KeyBackslash    Key = Key(ui.KeyBackslash)
-	KeyBackspace    Key = Key(ui.KeyBackspace)
-	KeyCapsLock     Key = Key(ui.KeyCapsLock)
-	KeyComma        Key = Key(ui.KeyComma)
-	KeyControl      Key = Key(ui.KeyControl)
-	KeyDelete       Key = Key(ui.KeyDelete)
-	KeyDown         Key = Key(ui.KeyDown)
-	KeyEnd          Key = Key(ui.KeyEnd)
-	KeyEnter        Key = Key(ui.KeyEnter)
-	KeyEqual        Key = Key(ui.KeyEqual)
-	KeyEscape       Key = Key(ui.KeyEscape)
-	KeyF1           Key = Key(ui.KeyF1)
-	KeyF2           Key = Key(ui.KeyF2)
-	KeyF3           Key = Key(ui.KeyF3)
-	KeyF4           Key = Key(ui.KeyF4)
-	KeyF5           Key = Key(ui.KeyF5)
-	KeyF6           Key = Key(ui.KeyF6)
-	KeyF7           Key = Key(ui.KeyF7)
-	KeyF8           Key = Key(ui.KeyF8)
-	KeyF9           Key = Key(ui.KeyF9)
-	KeyF10          Key = Key(ui.KeyF10)
-	KeyF11          Key = Key(ui.KeyF11)
-	KeyF12          Key = Key(ui.KeyF12)
-	KeyGraveAccent  Key = Key(ui.KeyGraveAccent)
-	KeyHome         Key = Key(ui.KeyHome)
-	KeyInsert       Key = Key(ui.KeyInsert)
-	KeyLeft         Key = Key(ui.KeyLeft)
-	KeyLeftBracket  Key = Key(ui.KeyLeftBracket)
-	KeyMinus        Key = Key(ui.KeyMinus)
-	KeyPageDown     Key = Key(ui.KeyPageDown)
-	KeyPageUp       Key = Key(ui.KeyPageUp)
-	KeyPeriod       Key = Key(ui.KeyPeriod)
-	KeyRight        Key = Key(ui.KeyRight)
-	KeyRightBracket Key = Key(ui.KeyRightBracket)
-	KeySemicolon    Key = Key(ui.KeySemicolon)
-	KeyShift        Key = Key(ui.KeyShift)
-	KeySlash        Key = Key(ui.KeySlash)
-	KeySpace        Key = Key(ui.KeySpace)
-	KeyTab          Key = Key(ui.KeyTab)
-	KeyUp           Key = Key(ui.KeyUp)
-	KeyMax          Key = KeyUp
+	Key0                  Key = Key(ui.Key0)
+	Key1                  Key = Key(ui.Key1)
+	Key2                  Key = Key(ui.Key2)
+	Key3                  Key = Key(ui.Key3)
+	Key4                  Key = Key(ui.Key4)
+	Key5                  Key = Key(ui.Key5)
+	Key6                  Key = Key(ui.Key6)
+	Key7                  Key = Key(ui.Key7)
+	Key8                  Key = Key(ui.Key8)
+	Key9                  Key = Key(ui.Key9)
+	KeyA                  Key = Key(ui.KeyA)
+	KeyB                  Key = Key(ui.KeyB)
+	KeyC                  Key = Key(ui.KeyC)
+	KeyD                  Key = Key(ui.KeyD)
+	KeyE                  Key = Key(ui.KeyE)
+	KeyF                  Key = Key(ui.KeyF)
+	KeyG                  Key = Key(ui.KeyG)
+	KeyH                  Key = Key(ui.KeyH)
+	KeyI                  Key = Key(ui.KeyI)
+	KeyJ                  Key = Key(ui.KeyJ)
+	KeyK                  Key = Key(ui.KeyK)
+	KeyL                  Key = Key(ui.KeyL)
+	KeyM                  Key = Key(ui.KeyM)
+	KeyN                  Key = Key(ui.KeyN)
+	KeyO                  Key = Key(ui.KeyO)
+	KeyP                  Key = Key(ui.KeyP)
+	KeyQ                  Key = Key(ui.KeyQ)
+	KeyR                  Key = Key(ui.KeyR)
+	KeyS                  Key = Key(ui.KeyS)
+	KeyT                  Key = Key(ui.KeyT)
+	KeyU                  Key = Key(ui.KeyU)
+	KeyV                  Key = Key(ui.KeyV)
+	KeyW                  Key = Key(ui.KeyW)
+	KeyX                  Key = Key(ui.KeyX)
+	KeyY                  Key = Key(ui.KeyY)
+	KeyZ                  Key = Key(ui.KeyZ)
+	KeyAlt                Key = Key(ui.KeyAlt)
+	KeyApostrophe         Key = Key(ui.KeyApostrophe)
+	KeyBackslash          Key = Key(ui.KeyBackslash)
+	KeyBackspace          Key = Key(ui.KeyBackspace)
+	KeyCapsLock           Key = Key(ui.KeyCapsLock)
+	KeyComma              Key = Key(ui.KeyComma)
+	KeyControl            Key = Key(ui.KeyControl)
+	KeyDelete             Key = Key(ui.KeyDelete)
+	KeyDown               Key = Key(ui.KeyDown)
+	KeyEnd                Key = Key(ui.KeyEnd)
+	KeyEnter              Key = Key(ui.KeyEnter)
+	KeyEqual              Key = Key(ui.KeyEqual)
+	KeyEscape             Key = Key(ui.KeyEscape)
+	KeyF1                 Key = Key(ui.KeyF1)
+	KeyF2                 Key = Key(ui.KeyF2)
+	KeyF3                 Key = Key(ui.KeyF3)
+	KeyF4                 Key = Key(ui.KeyF4)
+	KeyF5                 Key = Key(ui.KeyF5)
+	KeyF6                 Key = Key(ui.KeyF6)
+	KeyF7                 Key = Key(ui.KeyF7)
+	KeyF8                 Key = Key(ui.KeyF8)
+	KeyF9                 Key = Key(ui.KeyF9)
+	KeyF10                Key = Key(ui.KeyF10)
+	KeyF11                Key = Key(ui.KeyF11)
+	KeyF12                Key = Key(ui.KeyF12)
+	KeyGraveAccent        Key = Key(ui.KeyGraveAccent)
+	KeyHome               Key = Key(ui.KeyHome)
+	KeyInsert             Key = Key(ui.KeyInsert)
+	KeyLeft               Key = Key(ui.KeyLeft)
+	KeyLeftBracket        Key = Key(ui.KeyLeftBracket)
+	KeyMinus              Key = Key(ui.KeyMinus)
+	KeyPageDown           Key = Key(ui.KeyPageDown)
+	KeyPageUp             Key = Key(ui.KeyPageUp)
+	KeyPeriod             Key = Key(ui.KeyPeriod)
+	KeyRight              Key = Key(ui.KeyRight)
+	KeyRightBracket       Key = Key(ui.KeyRightBracket)
+	KeySemicolon          Key = Key(ui.KeySemicolon)
+	KeyShift              Key = Key(ui.KeyShift)
+	KeySlash              Key = Key(ui.KeySlash)
+	KeySpace              Key = Key(ui.KeySpace)
+	KeyTab                Key = Key(ui.KeyTab)
+	KeyUp                 Key = Key(ui.KeyUp)
+	KeyF13                Key = Key(ui.KeyF13)
+	KeyF14                Key = Key(ui.KeyF14)
+	KeyF15                Key = Key(ui.KeyF15)
+	KeyF16                Key = Key(ui.KeyF16)
+	KeyF17                Key = Key(ui.KeyF17)
+	KeyF18                Key = Key(ui.KeyF18)
+	KeyF19                Key = Key(ui.KeyF19)
+	KeyF20                Key = Key(ui.KeyF20)
+	KeyF21                Key = Key(ui.KeyF21)
+	KeyF22                Key = Key(ui.KeyF22)
+	KeyF23                Key = Key(ui.KeyF23)
+	KeyF24                Key = Key(ui.KeyF24)
+	KeyKP0                Key = Key(ui.KeyKP0)
+	KeyKP1                Key = Key(ui.KeyKP1)
+	KeyKP2                Key = Key(ui.KeyKP2)
+	KeyKP3                Key = Key(ui.KeyKP3)
+	KeyKP4                Key = Key(ui.KeyKP4)
+	KeyKP5                Key = Key(ui.KeyKP5)
+	KeyKP6                Key = Key(ui.KeyKP6)
+	KeyKP7                Key = Key(ui.KeyKP7)
+	KeyKP8                Key = Key(ui.KeyKP8)
+	KeyKP9                Key = Key(ui.KeyKP9)
+	KeyKPAdd              Key = Key(ui.KeyKPAdd)
+	KeyKPDecimal          Key = Key(ui.KeyKPDecimal)
+	KeyKPDivide           Key = Key(ui.KeyKPDivide)
+	KeyKPEnter            Key = Key(ui.KeyKPEnter)
+	KeyKPEqual            Key = Key(ui.KeyKPEqual)
+	KeyKPMultiply         Key = Key(ui.KeyKPMultiply)
+	KeyKPSubtract         Key = Key(ui.KeyKPSubtract)
+	KeyLeftAlt            Key = Key(ui.KeyLeftAlt)
+	KeyLeftControl        Key = Key(ui.KeyLeftControl)
+	KeyLeftShift          Key = Key(ui.KeyLeftShift)
+	KeyLeftSuper          Key = Key(ui.KeyLeftSuper)
+	KeyMediaPlayPause     Key = Key(ui.KeyMediaPlayPause)
+	KeyMediaStop          Key = Key(ui.KeyMediaStop)
+	KeyMediaTrackNext     Key = Key(ui.KeyMediaTrackNext)
+	KeyMediaTrackPrevious Key = Key(ui.KeyMediaTrackPrevious)
+	KeyMenu               Key = Key(ui.KeyMenu)
+	KeyNumLock            Key = Key(ui.KeyNumLock)
+	KeyPause              Key = Key(ui.KeyPause)
+	KeyPrintScreen        Key = Key(ui.KeyPrintScreen)
+	KeyRightAlt           Key = Key(ui.KeyRightAlt)
+	KeyRightControl       Key = Key(ui.KeyRightControl)
+	KeyRightShift         Key = Key(ui.KeyRightShift)
+	KeyRightSuper         Key = Key(ui.KeyRightSuper)
+	KeyScrollLock         Key = Key(ui.KeyScrollLock)
+	KeyVolumeDown         Key = Key(ui.KeyVolumeDown)
+	KeyVolumeMute         Key = Key(ui.KeyVolumeMute)
+	KeyVolumeUp           Key = Key(ui.KeyVolumeUp)
+	KeyMax                Key = KeyVolumeUp
 )
+
+// String returns the name of the key without the "Key" prefix, like "A" or "LeftShift".
+// The name doesn't depend on the keyboard layout: use KeyName to get the name on the user's layout.
+//
+// If k is not a defined key, String returns an empty string.
+func (k Key) String() string {
+	switch k {
+	case Key0:
+		return "0"
+	case Key1:
+		return "1"
+	case Key2:
+		return "2"
+	case Key3:
+		return "3"
+	case Key4:
+		return "4"
+	case Key5:
+		return "5"
+	case Key6:
+		return "6"
+	case Key7:
+		return "7"
+	case Key8:
+		return "8"
+	case Key9:
+		return "9"
+	case KeyA:
+		return "A"
+	case KeyB:
+		return "B"
+	case KeyC:
+		return "C"
+	case KeyD:
+		return "D"
+	case KeyE:
+		return "E"
+	case KeyF:
+		return "F"
+	case KeyG:
+		return "G"
+	case KeyH:
+		return "H"
+	case KeyI:
+		return "I"
+	case KeyJ:
+		return "J"
+	case KeyK:
+		return "K"
+	case KeyL:
+		return "L"
+	case KeyM:
+		return "M"
+	case KeyN:
+		return "N"
+	case KeyO:
+		return "O"
+	case KeyP:
+		return "P"
+	case KeyQ:
+		return "Q"
+	case KeyR:
+		return "R"
+	case KeyS:
+		return "S"
+	case KeyT:
+		return "T"
+	case KeyU:
+		return "U"
+	case KeyV:
+		return "V"
+	case KeyW:
+		return "W"
+	case KeyX:
+		return "X"
+	case KeyY:
+		return "Y"
+	case KeyZ:
+		return "Z"
+	case KeyAlt:
+		return "Alt"
+	case KeyApostrophe:
+		return "Apostrophe"
+	case KeyBackslash:
+		return "Backslash"
+	case KeyBackspace:
+		return "Backspace"
+	case KeyCapsLock:
+		return "CapsLock"
+	case KeyComma:
+		return "Comma"
+	case KeyControl:
+		return "Control"
+	case KeyDelete:
+		return "Delete"
+	case KeyDown:
+		return "Down"
+	case KeyEnd:
+		return "End"
+	case KeyEnter:
+		return "Enter"
+	case KeyEqual:
+		return "Equal"
+	case KeyEscape:
+		return "Escape"
+	case KeyF1:
+		return "F1"
+	case KeyF2:
+		return "F2"
+	case KeyF3:
+		return "F3"
+	case KeyF4:
+		return "F4"
+	case KeyF5:
+		return "F5"
+	case KeyF6:
+		return "F6"
+	case KeyF7:
+		return "F7"
+	case KeyF8:
+		return "F8"
+	case KeyF9:
+		return "F9"
+	case KeyF10:
+		return "F10"
+	case KeyF11:
+		return "F11"
+	case KeyF12:
+		return "F12"
+	case KeyGraveAccent:
+		return "GraveAccent"
+	case KeyHome:
+		return "Home"
+	case KeyInsert:
+		return "Insert"
+	case KeyLeft:
+		return "Left"
+	case KeyLeftBracket:
+		return "LeftBracket"
+	case KeyMinus:
+		return "Minus"
+	case KeyPageDown:
+		return "PageDown"
+	case KeyPageUp:
+		return "PageUp"
+	case KeyPeriod:
+		return "Period"
+	case KeyRight:
+		return "Right"
+	case KeyRightBracket:
+		return "RightBracket"
+	case KeySemicolon:
+		return "Semicolon"
+	case KeyShift:
+		return "Shift"
+	case KeySlash:
+		return "Slash"
+	case KeySpace:
+		return "Space"
+	case KeyTab:
+		return "Tab"
+	case KeyUp:
+		return "Up"
+	case KeyF13:
+		return "F13"
+	case KeyF14:
+		return "F14"
+	case KeyF15:
+		return "F15"
+	case KeyF16:
+		return "F16"
+	case KeyF17:
+		return "F17"
+	case KeyF18:
+		return "F18"
+	case KeyF19:
+		return "F19"
+	case KeyF20:
+		return "F20"
+	case KeyF21:
+		return "F21"
+	case KeyF22:
+		return "F22"
+	case KeyF23:
+		return "F23"
+	case KeyF24:
+		return "F24"
+	case KeyKP0:
+		return "KP0"
+	case KeyKP1:
+		return "KP1"
+	case KeyKP2:
+		return "KP2"
+	case KeyKP3:
+		return "KP3"
+	case KeyKP4:
+		return "KP4"
+	case KeyKP5:
+		return "KP5"
+	case KeyKP6:
+		return "KP6"
+	case KeyKP7:
+		return "KP7"
+	case KeyKP8:
+		return "KP8"
+	case KeyKP9:
+		return "KP9"
+	case KeyKPAdd:
+		return "KPAdd"
+	case KeyKPDecimal:
+		return "KPDecimal"
+	case KeyKPDivide:
+		return "KPDivide"
+	case KeyKPEnter:
+		return "KPEnter"
+	case KeyKPEqual:
+		return "KPEqual"
+	case KeyKPMultiply:
+		return "KPMultiply"
+	case KeyKPSubtract:
+		return "KPSubtract"
+	case KeyLeftAlt:
+		return "LeftAlt"
+	case KeyLeftControl:
+		return "LeftControl"
+	case KeyLeftShift:
+		return "LeftShift"
+	case KeyLeftSuper:
+		return "LeftSuper"
+	case KeyMediaPlayPause:
+		return "MediaPlayPause"
+	case KeyMediaStop:
+		return "MediaStop"
+	case KeyMediaTrackNext:
+		return "MediaTrackNext"
+	case KeyMediaTrackPrevious:
+		return "MediaTrackPrevious"
+	case KeyMenu:
+		return "Menu"
+	case KeyNumLock:
+		return "NumLock"
+	case KeyPause:
+		return "Pause"
+	case KeyPrintScreen:
+		return "PrintScreen"
+	case KeyRightAlt:
+		return "RightAlt"
+	case KeyRightControl:
+		return "RightControl"
+	case KeyRightShift:
+		return "RightShift"
+	case KeyRightSuper:
+		return "RightSuper"
+	case KeyScrollLock:
+		return "ScrollLock"
+	case KeyVolumeDown:
+		return "VolumeDown"
+	case KeyVolumeMute:
+		return "VolumeMute"
+	case KeyVolumeUp:
+		return "VolumeUp"
+	}
+	return ""
+}
+
+func keyNameToKey(name string) (Key, bool) {
+	switch strings.ToLower(name) {
+	case "0":
+		return Key0, true
+	case "1":
+		return Key1, true
+	case "2":
+		return Key2, true
+	case "3":
+		return Key3, true
+	case "4":
+		return Key4, true
+	case "5":
+		return Key5, true
+	case "6":
+		return Key6, true
+	case "7":
+		return Key7, true
+	case "8":
+		return Key8, true
+	case "9":
+		return Key9, true
+	case "a":
+		return KeyA, true
+	case "b":
+		return KeyB, true
+	case "c":
+		return KeyC, true
+	case "d":
+		return KeyD, true
+	case "e":
+		return KeyE, true
+	case "f":
+		return KeyF, true
+	case "g":
+		return KeyG, true
+	case "h":
+		return KeyH, true
+	case "i":
+		return KeyI, true
+	case "j":
+		return KeyJ, true
+	case "k":
+		return KeyK, true
+	case "l":
+		return KeyL, true
+	case "m":
+		return KeyM, true
+	case "n":
+		return KeyN, true
+	case "o":
+		return KeyO, true
+	case "p":
+		return KeyP, true
+	case "q":
+		return KeyQ, true
+	case "r":
+		return KeyR, true
+	case "s":
+		return KeyS, true
+	case "t":
+		return KeyT, true
+	case "u":
+		return KeyU, true
+	case "v":
+		return KeyV, true
+	case "w":
+		return KeyW, true
+	case "x":
+		return KeyX, true
+	case "y":
+		return KeyY, true
+	case "z":
+		return KeyZ, true
+	case "alt":
+		return KeyAlt, true
+	case "apostrophe":
+		return KeyApostrophe, true
+	case "backslash":
+		return KeyBackslash, true
+	case "backspace":
+		return KeyBackspace, true
+	case "capslock":
+		return KeyCapsLock, true
+	case "comma":
+		return KeyComma, true
+	case "control":
+		return KeyControl, true
+	case "delete":
+		return KeyDelete, true
+	case "down":
+		return KeyDown, true
+	case "end":
+		return KeyEnd, true
+	case "enter":
+		return KeyEnter, true
+	case "equal":
+		return KeyEqual, true
+	case "escape":
+		return KeyEscape, true
+	case "f1":
+		return KeyF1, true
+	case "f2":
+		return KeyF2, true
+	case "f3":
+		return KeyF3, true
+	case "f4":
+		return KeyF4, true
+	case "f5":
+		return KeyF5, true
+	case "f6":
+		return KeyF6, true
+	case "f7":
+		return KeyF7, true
+	case "f8":
+		return KeyF8, true
+	case "f9":
+		return KeyF9, true
+	case "f10":
+		return KeyF10, true
+	case "f11":
+		return KeyF11, true
+	case "f12":
+		return KeyF12, true
+	case "graveaccent":
+		return KeyGraveAccent, true
+	case "home":
+		return KeyHome, true
+	case "insert":
+		return KeyInsert, true
+	case "left":
+		return KeyLeft, true
+	case "leftbracket":
+		return KeyLeftBracket, true
+	case "minus":
+		return KeyMinus, true
+	case "pagedown":
+		return KeyPageDown, true
+	case "pageup":
+		return KeyPageUp, true
+	case "period":
+		return KeyPeriod, true
+	case "right":
+		return KeyRight, true
+	case "rightbracket":
+		return KeyRightBracket, true
+	case "semicolon":
+		return KeySemicolon, true
+	case "shift":
+		return KeyShift, true
+	case "slash":
+		return KeySlash, true
+	case "space":
+		return KeySpace, true
+	case "tab":
+		return KeyTab, true
+	case "up":
+		return KeyUp, true
+	case "f13":
+		return KeyF13, true
+	case "f14":
+		return KeyF14, true
+	case "f15":
+		return KeyF15, true
+	case "f16":
+		return KeyF16, true
+	case "f17":
+		return KeyF17, true
+	case "f18":
+		return KeyF18, true
+	case "f19":
+		return KeyF19, true
+	case "f20":
+		return KeyF20, true
+	case "f21":
+		return KeyF21, true
+	case "f22":
+		return KeyF22, true
+	case "f23":
+		return KeyF23, true
+	case "f24":
+		return KeyF24, true
+	case "kp0":
+		return KeyKP0, true
+	case "kp1":
+		return KeyKP1, true
+	case "kp2":
+		return KeyKP2, true
+	case "kp3":
+		return KeyKP3, true
+	case "kp4":
+		return KeyKP4, true
+	case "kp5":
+		return KeyKP5, true
+	case "kp6":
+		return KeyKP6, true
+	case "kp7":
+		return KeyKP7, true
+	case "kp8":
+		return KeyKP8, true
+	case "kp9":
+		return KeyKP9, true
+	case "kpadd":
+		return KeyKPAdd, true
+	case "kpdecimal":
+		return KeyKPDecimal, true
+	case "kpdivide":
+		return KeyKPDivide, true
+	case "kpenter":
+		return KeyKPEnter, true
+	case "kpequal":
+		return KeyKPEqual, true
+	case "kpmultiply":
+		return KeyKPMultiply, true
+	case "kpsubtract":
+		return KeyKPSubtract, true
+	case "leftalt":
+		return KeyLeftAlt, true
+	case "leftcontrol":
+		return KeyLeftControl, true
+	case "leftshift":
+		return KeyLeftShift, true
+	case "leftsuper":
+		return KeyLeftSuper, true
+	case "mediaplaypause":
+		return KeyMediaPlayPause, true
+	case "mediastop":
+		return KeyMediaStop, true
+	case "mediatracknext":
+		return KeyMediaTrackNext, true
+	case "mediatrackprevious":
+		return KeyMediaTrackPrevious, true
+	case "menu":
+		return KeyMenu, true
+	case "numlock":
+		return KeyNumLock, true
+	case "pause":
+		return KeyPause, true
+	case "printscreen":
+		return KeyPrintScreen, true
+	case "rightalt":
+		return KeyRightAlt, true
+	case "rightcontrol":
+		return KeyRightControl, true
+	case "rightshift":
+		return KeyRightShift, true
+	case "rightsuper":
+		return KeyRightSuper, true
+	case "scrolllock":
+		return KeyScrollLock, true
+	case "volumedown":
+		return KeyVolumeDown, true
+	case "volumemute":
+		return KeyVolumeMute, true
+	case "volumeup":
+		return KeyVolumeUp, true
+	}
+	return 0, false
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k Key) MarshalText() ([]byte, error) {
+	s := k.String()
+	if s == "" {
+		return nil, fmt.Errorf("ebiten: undefined key: %d", int(k))
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The name is case-insensitive.
+func (k *Key) UnmarshalText(text []byte) error {
+	key, ok := keyNameToKey(string(text))
+	if !ok {
+		return fmt.Errorf("ebiten: unexpected key name: %s", string(text))
+	}
+	*k = key
+	return nil
+}
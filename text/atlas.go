@@ -0,0 +1,390 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+	emath "github.com/dave/ebiten/internal/math"
+)
+
+// pageSize is the width and the height of a glyph cache page.
+//
+// Don't use ebiten.MaxImageSize here.
+// It's because the back-end image pixels will be restored from GPU
+// whenever a new glyph is rendered on the image, and restoring cost is
+// expensive if the image is big.
+// The back-end image is updated a temporary image, and the temporary image is
+// always cleared after used. This means that there is no clue to restore
+// the back-end image without reading from GPU
+// (see the package 'restorable' implementation).
+//
+// TODO: How about making a new function for 'flagile' image?
+const pageSize = 1024
+
+// glyphPadding is the gap between glyphs in a page
+// so that a glyph drawn with linear filtering doesn't sample its neighbors.
+const glyphPadding = 1
+
+const defaultMaxPages = 4
+
+var (
+	maxPages = defaultMaxPages
+
+	// glyphs is the glyphs cached in the pages.
+	glyphs = map[char]*glyph{}
+
+	// pages is the glyph cache pages shared among all the faces.
+	// The key represents whether the pages are for signed distance fields.
+	pages = map[bool][]*page{}
+
+	// tmpImages is the temporary images as renderer sources for glyphs.
+	// The key is the size of the image, which is always power of 2.
+	tmpImages = map[int]*ebiten.Image{}
+
+	emptyImage *ebiten.Image
+)
+
+// SetMaxGlyphCachePages sets the maximum number of the glyph cache pages.
+//
+// A page is a 1024x1024 image, and the glyphs of all the faces are packed into the pages.
+// When all the pages are full, the glyphs in the least recently used region are evicted.
+// The glyphs of signed distance field faces are cached in separate pages,
+// and n is the maximum number for each kind of pages.
+//
+// The default value is 4.
+//
+// SetMaxGlyphCachePages panics if n is less than 1.
+//
+// This function is concurrent-safe.
+func SetMaxGlyphCachePages(n int) {
+	if n < 1 {
+		panic("text: the number of pages must be positive")
+	}
+
+	textM.Lock()
+	defer textM.Unlock()
+
+	maxPages = n
+	for sdf, ps := range pages {
+		if len(ps) <= n {
+			continue
+		}
+		for _, p := range ps[n:] {
+			p.reset()
+			p.image.Dispose()
+		}
+		pages[sdf] = ps[:n]
+	}
+}
+
+type glyph struct {
+	char  char
+	shelf *shelf
+
+	// x and y are the position of the glyph in the page.
+	x int
+	y int
+}
+
+// draw draws the glyph at the dot position (x, y) scaled by scale with the color matrix cm.
+// If op is not nil, op is composed after them.
+func (g *glyph) draw(dst *ebiten.Image, x, y fixed.Int26_6, scale float64, cm ebiten.ColorM, op *ebiten.DrawImageOptions) {
+	dst.DrawImage(g.shelf.page.image, g.drawImageOptions(x, y, scale, cm, op))
+}
+
+// drawImageOptions returns the options to draw the glyph at the dot position (x, y).
+// The glyph's placement and color are applied first, and then op is composed.
+func (g *glyph) drawImageOptions(x, y fixed.Int26_6, scale float64, cm ebiten.ColorM, op *ebiten.DrawImageOptions) *ebiten.DrawImageOptions {
+	b := g.char.bounds()
+	o := &ebiten.DrawImageOptions{}
+	o.GeoM.Scale(scale, scale)
+	o.GeoM.Translate(fixed26_6ToFloat64(x)+fixed26_6ToFloat64(b.Min.X)*scale, fixed26_6ToFloat64(y)+fixed26_6ToFloat64(b.Min.Y)*scale)
+	o.ColorM = cm
+
+	w, h := g.char.size()
+	r := image.Rect(g.x, g.y, g.x+w.Ceil(), g.y+h.Ceil())
+	o.SourceRect = &r
+
+	concatDrawImageOptions(o, op)
+	return o
+}
+
+// render renders the glyph on the page.
+func (g *glyph) render() {
+	sw, sh := g.char.size()
+	w, h := sw.Ceil(), sh.Ceil()
+	size := w
+	if size < h {
+		size = h
+	}
+	// Different images for small runes are inefficient.
+	// Let's use a same temporary image for typical character sizes.
+	if size < 32 {
+		size = 32
+	}
+	size = emath.NextPowerOf2Int(size)
+
+	tmp, ok := tmpImages[size]
+	if !ok {
+		tmp, _ = ebiten.NewImage(size, size, ebiten.FilterNearest)
+		tmpImages[size] = tmp
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.White,
+		Face: g.char.face,
+	}
+	b := g.char.bounds()
+	d.Dot = fixed.Point26_6{-b.Min.X, -b.Min.Y}
+	d.DrawString(string(g.char.rune))
+	if g.char.outline > 0 {
+		dilate(dst, w, h, g.char.outline)
+	}
+	if g.char.sdf {
+		generateSDF(dst, w, h)
+	}
+	tmp.ReplacePixels(dst.Pix)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(g.x), float64(g.y))
+	r := image.Rect(0, 0, w, h)
+	op.SourceRect = &r
+	op.CompositeMode = ebiten.CompositeModeCopy
+	g.shelf.page.image.DrawImage(tmp, op)
+
+	tmp.Clear()
+}
+
+// page is an image that glyphs are packed into.
+//
+// A page is divided into shelves from top to bottom.
+type page struct {
+	image   *ebiten.Image
+	shelves []*shelf
+}
+
+func newPage(sdf bool) *page {
+	filter := ebiten.FilterNearest
+	if sdf {
+		// Signed distance fields are interpolated linearly to be thresholded smoothly.
+		filter = ebiten.FilterLinear
+	}
+	i, _ := ebiten.NewImage(pageSize, pageSize, filter)
+	return &page{
+		image: i,
+	}
+}
+
+// shelfFor returns a shelf that has a room for a glyph of size (w, h), or nil if there is no such shelf.
+func (p *page) shelfFor(w, h int) *shelf {
+	var best *shelf
+	for _, s := range p.shelves {
+		if s.height < h || pageSize-s.x < w {
+			continue
+		}
+		if best == nil || s.height < best.height {
+			best = s
+		}
+	}
+	// Avoid wasting a shelf much taller than the glyph if a new shelf can be added.
+	if best != nil && best.height <= h+h/2 {
+		return best
+	}
+
+	y := 0
+	if n := len(p.shelves); n > 0 {
+		y = p.shelves[n-1].y + p.shelves[n-1].height
+	}
+	if pageSize-y < h {
+		return best
+	}
+	// Round up the height so that the shelf can be reused for glyphs of similar sizes.
+	sh := (h + 3) &^ 3
+	if sh > pageSize-y {
+		sh = pageSize - y
+	}
+	s := &shelf{
+		page:   p,
+		y:      y,
+		height: sh,
+	}
+	p.shelves = append(p.shelves, s)
+	return s
+}
+
+// atime returns the last time when a glyph in the page was used.
+func (p *page) atime() int64 {
+	var t int64
+	for _, s := range p.shelves {
+		if t < s.atime {
+			t = s.atime
+		}
+	}
+	return t
+}
+
+// empty returns a boolean value indicating whether the page has no glyphs.
+func (p *page) empty() bool {
+	for _, s := range p.shelves {
+		if len(s.glyphs) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// reset evicts all the glyphs in the page and removes the shelves.
+func (p *page) reset() {
+	for _, s := range p.shelves {
+		for _, g := range s.glyphs {
+			delete(glyphs, g.char)
+		}
+		cacheEvictions += len(s.glyphs)
+	}
+	p.shelves = nil
+	p.image.Clear()
+}
+
+// shelf is a horizontal region of a page. The glyphs in a shelf are placed from left to right.
+//
+// A shelf is the unit of eviction.
+type shelf struct {
+	page   *page
+	y      int
+	height int
+
+	// x is the left edge of the free space in the shelf.
+	x int
+
+	glyphs []*glyph
+	atime  int64
+}
+
+// reset evicts all the glyphs in the shelf.
+func (s *shelf) reset() {
+	for _, g := range s.glyphs {
+		delete(glyphs, g.char)
+	}
+	cacheEvictions += len(s.glyphs)
+	s.glyphs = nil
+	s.x = 0
+
+	if emptyImage == nil {
+		emptyImage, _ = ebiten.NewImage(16, 16, ebiten.FilterNearest)
+	}
+	w, h := emptyImage.Size()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(pageSize)/float64(w), float64(s.height)/float64(h))
+	op.GeoM.Translate(0, float64(s.y))
+	op.CompositeMode = ebiten.CompositeModeClear
+	s.page.image.DrawImage(emptyImage, op)
+}
+
+// allocShelf returns a shelf that has a room for a glyph of size (w, h).
+// allocShelf adds a page or evicts glyphs if needed.
+func allocShelf(sdf bool, w, h int) *shelf {
+	for _, p := range pages[sdf] {
+		if s := p.shelfFor(w, h); s != nil {
+			return s
+		}
+	}
+
+	if len(pages[sdf]) < maxPages {
+		p := newPage(sdf)
+		pages[sdf] = append(pages[sdf], p)
+		return p.shelfFor(w, h)
+	}
+
+	// Evict the least recently used shelf that is tall enough.
+	var oldest *shelf
+	for _, p := range pages[sdf] {
+		for _, s := range p.shelves {
+			if s.height < h {
+				continue
+			}
+			if oldest == nil || s.atime < oldest.atime {
+				oldest = s
+			}
+		}
+	}
+	if oldest != nil {
+		oldest.reset()
+		return oldest
+	}
+
+	// No shelf is tall enough. Evict the least recently used page.
+	var oldestPage *page
+	t := int64(math.MaxInt64)
+	for _, p := range pages[sdf] {
+		if pt := p.atime(); pt < t {
+			t = pt
+			oldestPage = p
+		}
+	}
+	oldestPage.reset()
+	return oldestPage.shelfFor(w, h)
+}
+
+func getGlyphFromCache(face font.Face, r rune, sdf bool, outline int, now int64) *glyph {
+	ch := char{
+		face:    face,
+		rune:    r,
+		sdf:     sdf,
+		outline: outline,
+	}
+	if g, ok := glyphs[ch]; ok {
+		g.shelf.atime = now
+		cacheHits++
+		return g
+	}
+
+	if ch.empty() {
+		// The glyph doesn't have its size but might have valid 'advance' parameter
+		// when ch is e.g. space (U+0020).
+		return &glyph{
+			char: ch,
+		}
+	}
+
+	sw, sh := ch.size()
+	w, h := sw.Ceil()+glyphPadding, sh.Ceil()+glyphPadding
+	if w > pageSize || h > pageSize {
+		// The glyph is too big to be cached.
+		return nil
+	}
+
+	cacheMisses++
+	s := allocShelf(sdf, w, h)
+	g := &glyph{
+		char:  ch,
+		shelf: s,
+		x:     s.x,
+		y:     s.y,
+	}
+	s.x += w
+	s.glyphs = append(s.glyphs, g)
+	s.atime = now
+	glyphs[ch] = g
+	g.render()
+	return g
+}
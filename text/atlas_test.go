@@ -0,0 +1,80 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+
+	"github.com/dave/ebiten"
+	. "github.com/dave/ebiten/text"
+)
+
+func newSquareFace(size int, n int) *basicfont.Face {
+	return &basicfont.Face{
+		Advance: size,
+		Width:   size,
+		Height:  size,
+		Ascent:  size,
+		Mask:    image.NewAlpha(image.Rect(0, 0, size, size*n)),
+		Ranges: []basicfont.Range{
+			{Low: 'a', High: 'a' + rune(n), Offset: 0},
+		},
+	}
+}
+
+func TestGlyphCacheEviction(t *testing.T) {
+	SetMaxGlyphCachePages(1)
+	defer SetMaxGlyphCachePages(4)
+
+	// A page can have only 9 glyphs of this face.
+	big := newSquareFace(300, 26)
+	small := newSquareFace(10, 26)
+	// This face's glyphs are bigger than a page and are never cached.
+	huge := newSquareFace(2000, 1)
+
+	ResetGlyphCacheStats()
+	dst, _ := ebiten.NewImage(16, 16, ebiten.FilterNearest)
+	for i := 0; i < 3; i++ {
+		Draw(dst, "abcdefghijklmnopqrstuvwxyz", small, 0, 0, color.White)
+		Draw(dst, "abcdefghijklmnopqrstuvwxyz", big, 0, 0, color.White)
+		Draw(dst, "a", huge, 0, 0, color.White)
+	}
+
+	s := ReadGlyphCacheStats()
+	if s.Evictions == 0 {
+		t.Errorf("Evictions: got: 0, want: > 0")
+	}
+	// The glyphs of the big face never stay in the cache, and are rendered every time.
+	if got, want := s.Misses, 3*26; got < want {
+		t.Errorf("Misses: got: %d, want: >= %d", got, want)
+	}
+	// There is at most one page for the regular glyphs and one page for the signed distance fields.
+	if got, want := s.Pages, 2; got > want {
+		t.Errorf("Pages: got: %d, want: <= %d", got, want)
+	}
+}
+
+func TestSetMaxGlyphCachePagesPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetMaxGlyphCachePages(0) must panic")
+		}
+	}()
+	SetMaxGlyphCachePages(0)
+}
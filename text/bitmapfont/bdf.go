@@ -0,0 +1,230 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitmapfont
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dave/ebiten"
+)
+
+type bdfChar struct {
+	encoding int
+	advance  int
+	width    int
+	height   int
+	xoffset  int
+	yoffset  int
+	bitmap   [][]byte
+}
+
+const (
+	// bdfPageWidth is the width of the page images that the glyphs of a BDF font are packed into.
+	bdfPageWidth = 512
+
+	// bdfMaxPageHeight is the maximum height of a page image.
+	// A page image is converted into an Ebiten image, so the height can't exceed ebiten.MaxImageSize.
+	bdfMaxPageHeight = ebiten.MaxImageSize
+)
+
+// ParseBDF parses a font in the Glyph Bitmap Distribution Format (BDF).
+//
+// The glyphs with negative encodings, which are not in the font's encoding, are ignored.
+// BDF doesn't have kerning data, so the face's Kern always returns 0.
+func ParseBDF(r io.Reader) (*Face, error) {
+	var chars []*bdfChar
+	var c *bdfChar
+	ascent, descent := 0, 0
+	var hasAscent, hasDescent bool
+	var boundingBox [4]int
+	inBitmap := false
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if inBitmap {
+			if fields[0] == "ENDCHAR" {
+				inBitmap = false
+				if c.encoding >= 0 {
+					chars = append(chars, c)
+				}
+				c = nil
+				continue
+			}
+			row, err := hex.DecodeString(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("bitmapfont: invalid bitmap row %q", fields[0])
+			}
+			c.bitmap = append(c.bitmap, row)
+			continue
+		}
+
+		ints := func(n int) ([]int, error) {
+			if len(fields) < n+1 {
+				return nil, fmt.Errorf("bitmapfont: too few values: %q", s.Text())
+			}
+			vs := make([]int, n)
+			for i := range vs {
+				v, err := strconv.Atoi(fields[i+1])
+				if err != nil {
+					return nil, fmt.Errorf("bitmapfont: invalid value: %q", s.Text())
+				}
+				vs[i] = v
+			}
+			return vs, nil
+		}
+
+		switch fields[0] {
+		case "FONTBOUNDINGBOX":
+			vs, err := ints(4)
+			if err != nil {
+				return nil, err
+			}
+			copy(boundingBox[:], vs)
+		case "FONT_ASCENT":
+			vs, err := ints(1)
+			if err != nil {
+				return nil, err
+			}
+			ascent, hasAscent = vs[0], true
+		case "FONT_DESCENT":
+			vs, err := ints(1)
+			if err != nil {
+				return nil, err
+			}
+			descent, hasDescent = vs[0], true
+		case "STARTCHAR":
+			c = &bdfChar{
+				encoding: -1,
+			}
+		case "ENCODING", "DWIDTH", "BBX", "BITMAP":
+			if c == nil {
+				return nil, fmt.Errorf("bitmapfont: %s outside of a char", fields[0])
+			}
+			switch fields[0] {
+			case "ENCODING":
+				vs, err := ints(1)
+				if err != nil {
+					return nil, err
+				}
+				c.encoding = vs[0]
+			case "DWIDTH":
+				vs, err := ints(1)
+				if err != nil {
+					return nil, err
+				}
+				c.advance = vs[0]
+			case "BBX":
+				vs, err := ints(4)
+				if err != nil {
+					return nil, err
+				}
+				c.width, c.height, c.xoffset, c.yoffset = vs[0], vs[1], vs[2], vs[3]
+			case "BITMAP":
+				inBitmap = true
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if inBitmap {
+		return nil, errors.New("bitmapfont: unexpected end of the BDF")
+	}
+
+	if !hasAscent {
+		ascent = boundingBox[1] + boundingBox[3]
+	}
+	if !hasDescent {
+		descent = -boundingBox[3]
+	}
+
+	f := newFace()
+	f.ascent = ascent
+	f.descent = descent
+	f.lineHeight = ascent + descent
+
+	// Pack the glyphs into pages in rows.
+	// A new page is started when the next row doesn't fit with the current page.
+	x, y, rowHeight := 0, 0, 0
+	positions := make([]image.Point, len(chars))
+	pageIndices := make([]int, len(chars))
+	pageHeights := []int{0}
+	for i, c := range chars {
+		if c.width > bdfPageWidth {
+			return nil, fmt.Errorf("bitmapfont: too wide glyph for the encoding %d", c.encoding)
+		}
+		if c.height > bdfMaxPageHeight {
+			return nil, fmt.Errorf("bitmapfont: too tall glyph for the encoding %d", c.encoding)
+		}
+		if x+c.width > bdfPageWidth {
+			x = 0
+			y += rowHeight + 1
+			rowHeight = 0
+		}
+		if y+c.height > bdfMaxPageHeight {
+			x, y, rowHeight = 0, 0, 0
+			pageHeights = append(pageHeights, 0)
+		}
+		positions[i] = image.Pt(x, y)
+		pageIndices[i] = len(pageHeights) - 1
+		x += c.width + 1
+		if rowHeight < c.height {
+			rowHeight = c.height
+		}
+		if h := y + rowHeight; pageHeights[len(pageHeights)-1] < h {
+			pageHeights[len(pageHeights)-1] = h
+		}
+	}
+
+	pages := make([]*image.Alpha, len(pageHeights))
+	for i, h := range pageHeights {
+		pages[i] = image.NewAlpha(image.Rect(0, 0, bdfPageWidth, h))
+	}
+	for i, c := range chars {
+		p := positions[i]
+		page := pages[pageIndices[i]]
+		for j, row := range c.bitmap {
+			if j >= c.height {
+				break
+			}
+			for k := 0; k < c.width && k/8 < len(row); k++ {
+				if row[k/8]&(0x80>>uint(k%8)) != 0 {
+					page.Pix[page.PixOffset(p.X+k, p.Y+j)] = 0xff
+				}
+			}
+		}
+		f.glyphs[rune(c.encoding)] = &glyph{
+			page:    pageIndices[i],
+			src:     image.Rect(p.X, p.Y, p.X+c.width, p.Y+c.height),
+			offset:  image.Pt(c.xoffset, -(c.yoffset + c.height)),
+			advance: c.advance,
+		}
+	}
+	for _, p := range pages {
+		f.pages = append(f.pages, p)
+	}
+	return f, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bitmapfont provides font faces of pre-rendered bitmap fonts.
+//
+// The supported formats are AngelCode BMFont (the text, XML and binary formats) and BDF.
+// The faces implement text.GlyphImager, so the text package draws their glyphs directly from the fonts' page images
+// without rasterizing them again.
+//
+// Note: This package is experimental and API might be changed.
+package bitmapfont
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+)
+
+type glyph struct {
+	page    int
+	src     image.Rectangle
+	offset  image.Point // The position of src's upper-left corner relative to the dot.
+	advance int
+}
+
+type kerningPair struct {
+	first  rune
+	second rune
+}
+
+// Face is a font face of a bitmap font.
+//
+// Face implements font.Face and text.GlyphImager.
+type Face struct {
+	glyphs   map[rune]*glyph
+	kernings map[kerningPair]int
+	pages    []image.Image
+
+	// ebitenPages is created lazily since Ebiten images can't be created before the game starts.
+	ebitenPages []*ebiten.Image
+
+	lineHeight int
+	ascent     int
+	descent    int
+}
+
+func newFace() *Face {
+	return &Face{
+		glyphs:   map[rune]*glyph{},
+		kernings: map[kerningPair]int{},
+	}
+}
+
+// Close implements font.Face.
+//
+// Close disposes the Ebiten images of the pages.
+func (f *Face) Close() error {
+	for _, p := range f.ebitenPages {
+		if p != nil {
+			if err := p.Dispose(); err != nil {
+				return err
+			}
+		}
+	}
+	f.ebitenPages = nil
+	return nil
+}
+
+// Glyph implements font.Face.
+func (f *Face) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	g, ok := f.glyphs[r]
+	if !ok {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	p := image.Pt(dot.X.Round(), dot.Y.Round()).Add(g.offset)
+	dr = image.Rectangle{Min: p, Max: p.Add(g.src.Size())}
+	return dr, f.pages[g.page], g.src.Min, fixed.I(g.advance), true
+}
+
+// GlyphBounds implements font.Face.
+func (f *Face) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	g, ok := f.glyphs[r]
+	if !ok {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	s := g.src.Size()
+	return fixed.R(g.offset.X, g.offset.Y, g.offset.X+s.X, g.offset.Y+s.Y), fixed.I(g.advance), true
+}
+
+// GlyphAdvance implements font.Face.
+func (f *Face) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	g, ok := f.glyphs[r]
+	if !ok {
+		return 0, false
+	}
+	return fixed.I(g.advance), true
+}
+
+// Kern implements font.Face.
+func (f *Face) Kern(r0, r1 rune) fixed.Int26_6 {
+	return fixed.I(f.kernings[kerningPair{r0, r1}])
+}
+
+// Metrics implements font.Face.
+func (f *Face) Metrics() font.Metrics {
+	return font.Metrics{
+		Height:  fixed.I(f.lineHeight),
+		Ascent:  fixed.I(f.ascent),
+		Descent: fixed.I(f.descent),
+	}
+}
+
+// GlyphImage implements text.GlyphImager.
+//
+// The page images are converted into Ebiten images when GlyphImage is called first.
+func (f *Face) GlyphImage(r rune) (img *ebiten.Image, src image.Rectangle, offset image.Point, ok bool) {
+	g, ok := f.glyphs[r]
+	if !ok || g.src.Empty() {
+		return nil, image.Rectangle{}, image.Point{}, false
+	}
+	if f.ebitenPages == nil {
+		f.ebitenPages = make([]*ebiten.Image, len(f.pages))
+	}
+	if f.ebitenPages[g.page] == nil {
+		p, err := ebiten.NewImageFromImage(f.pages[g.page], ebiten.FilterNearest)
+		if err != nil {
+			return nil, image.Rectangle{}, image.Point{}, false
+		}
+		f.ebitenPages[g.page] = p
+	}
+	// The bounds of the page image might not start at (0, 0).
+	b := f.pages[g.page].Bounds()
+	return f.ebitenPages[g.page], g.src.Sub(b.Min), g.offset, true
+}
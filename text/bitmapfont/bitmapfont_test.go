@@ -0,0 +1,240 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitmapfont_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/text"
+	. "github.com/dave/ebiten/text/bitmapfont"
+)
+
+var _ text.GlyphImager = (*Face)(nil)
+
+func openPage(t *testing.T) func(name string) (io.ReadCloser, error) {
+	return func(name string) (io.ReadCloser, error) {
+		if name != "page.png" {
+			t.Errorf("page name: got: %q, want: %q", name, "page.png")
+		}
+		buf := &bytes.Buffer{}
+		if err := png.Encode(buf, image.NewNRGBA(image.Rect(0, 0, 64, 64))); err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(buf), nil
+	}
+}
+
+const bmfontText = `info face="Test Font" size=16 bold=0
+common lineHeight=16 base=12 scaleW=64 scaleH=64 pages=1 packed=0
+page id=0 file="page.png"
+chars count=2
+char id=65 x=1 y=2 width=7 height=9 xoffset=1 yoffset=3 xadvance=9 page=0 chnl=15
+char id=86 x=10 y=2 width=8 height=9 xoffset=0 yoffset=3 xadvance=8 page=0 chnl=15
+kernings count=1
+kerning first=65 second=86 amount=-2
+`
+
+const bmfontXML = `<?xml version="1.0"?>
+<font>
+  <info face="Test Font" size="16"/>
+  <common lineHeight="16" base="12" scaleW="64" scaleH="64" pages="1" packed="0"/>
+  <pages>
+    <page id="0" file="page.png"/>
+  </pages>
+  <chars count="2">
+    <char id="65" x="1" y="2" width="7" height="9" xoffset="1" yoffset="3" xadvance="9" page="0" chnl="15"/>
+    <char id="86" x="10" y="2" width="8" height="9" xoffset="0" yoffset="3" xadvance="8" page="0" chnl="15"/>
+  </chars>
+  <kernings count="1">
+    <kerning first="65" second="86" amount="-2"/>
+  </kernings>
+</font>
+`
+
+func bmfontBinary() []byte {
+	buf := &bytes.Buffer{}
+	buf.Write([]byte{'B', 'M', 'F', 3})
+	block := func(t byte, data ...interface{}) {
+		b := &bytes.Buffer{}
+		for _, d := range data {
+			binary.Write(b, binary.LittleEndian, d)
+		}
+		buf.WriteByte(t)
+		binary.Write(buf, binary.LittleEndian, uint32(b.Len()))
+		buf.Write(b.Bytes())
+	}
+	block(2, uint16(16), uint16(12), uint16(64), uint16(64), uint16(1), uint8(0), uint8(0), uint8(0), uint8(0), uint8(0))
+	block(3, []byte("page.png\x00"))
+	block(4,
+		uint32(65), uint16(1), uint16(2), uint16(7), uint16(9), int16(1), int16(3), int16(9), uint8(0), uint8(15),
+		uint32(86), uint16(10), uint16(2), uint16(8), uint16(9), int16(0), int16(3), int16(8), uint8(0), uint8(15))
+	block(5, uint32(65), uint32(86), int16(-2))
+	return buf.Bytes()
+}
+
+func TestParseBMFont(t *testing.T) {
+	for _, c := range []struct {
+		Name string
+		Data []byte
+	}{
+		{"text", []byte(bmfontText)},
+		{"xml", []byte(bmfontXML)},
+		{"binary", bmfontBinary()},
+	} {
+		f, err := ParseBMFont(bytes.NewReader(c.Data), openPage(t))
+		if err != nil {
+			t.Errorf("%s: %v", c.Name, err)
+			continue
+		}
+		m := f.Metrics()
+		if m.Height != fixed.I(16) || m.Ascent != fixed.I(12) || m.Descent != fixed.I(4) {
+			t.Errorf("%s: Metrics(): got: %v", c.Name, m)
+		}
+		b, a, ok := f.GlyphBounds('A')
+		if want := fixed.R(1, -9, 8, 0); !ok || b != want || a != fixed.I(9) {
+			t.Errorf("%s: GlyphBounds('A'): got: %v, %v, %v, want: %v, %v, true", c.Name, b, a, ok, want, fixed.I(9))
+		}
+		if got, want := f.Kern('A', 'V'), fixed.I(-2); got != want {
+			t.Errorf("%s: Kern('A', 'V'): got: %v, want: %v", c.Name, got, want)
+		}
+		if got := f.Kern('V', 'A'); got != 0 {
+			t.Errorf("%s: Kern('V', 'A'): got: %v, want: 0", c.Name, got)
+		}
+		if _, ok := f.GlyphAdvance('B'); ok {
+			t.Errorf("%s: GlyphAdvance('B') must return false", c.Name)
+		}
+		dr, _, maskp, _, ok := f.Glyph(fixed.P(10, 20), 'V')
+		if !ok || dr != image.Rect(10, 11, 18, 20) || maskp != image.Pt(10, 2) {
+			t.Errorf("%s: Glyph('V'): got: %v, %v, %v", c.Name, dr, maskp, ok)
+		}
+	}
+}
+
+const bdf = `STARTFONT 2.1
+FONT -test-font
+SIZE 8 75 75
+FONTBOUNDINGBOX 8 8 0 -1
+STARTPROPERTIES 2
+FONT_ASCENT 7
+FONT_DESCENT 1
+ENDPROPERTIES
+CHARS 2
+STARTCHAR A
+ENCODING 65
+SWIDTH 500 0
+DWIDTH 6 0
+BBX 5 7 0 0
+BITMAP
+20
+50
+88
+F8
+88
+88
+88
+ENDCHAR
+STARTCHAR unencoded
+ENCODING -1
+DWIDTH 6 0
+BBX 1 1 0 0
+BITMAP
+80
+ENDCHAR
+ENDFONT
+`
+
+func TestParseBDF(t *testing.T) {
+	f, err := ParseBDF(strings.NewReader(bdf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := f.Metrics()
+	if m.Height != fixed.I(8) || m.Ascent != fixed.I(7) || m.Descent != fixed.I(1) {
+		t.Errorf("Metrics(): got: %v", m)
+	}
+	b, a, ok := f.GlyphBounds('A')
+	if want := fixed.R(0, -7, 5, 0); !ok || b != want || a != fixed.I(6) {
+		t.Errorf("GlyphBounds('A'): got: %v, %v, %v, want: %v, %v, true", b, a, ok, want, fixed.I(6))
+	}
+	dr, mask, maskp, _, ok := f.Glyph(fixed.P(0, 7), 'A')
+	if !ok || dr != image.Rect(0, 0, 5, 7) {
+		t.Fatalf("Glyph('A'): got: %v, %v", dr, ok)
+	}
+	// The top row is 0x20, i.e., only the third pixel is set.
+	for x := 0; x < 5; x++ {
+		_, _, _, a := mask.At(maskp.X+x, maskp.Y).RGBA()
+		if got, want := a != 0, x == 2; got != want {
+			t.Errorf("mask at (%d, 0): got: %v, want: %v", x, got, want)
+		}
+	}
+	if _, ok := f.GlyphAdvance(-1); ok {
+		t.Errorf("GlyphAdvance(-1) must return false")
+	}
+}
+
+func TestParseBDFPages(t *testing.T) {
+	// 500 glyphs of 64x64 pixels don't fit with one page image of ebiten.MaxImageSize.
+	const (
+		n    = 500
+		size = 64
+	)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "STARTFONT 2.1\nFONTBOUNDINGBOX %d %d 0 0\nCHARS %d\n", size, size, n)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&buf, "STARTCHAR c%d\nENCODING %d\nDWIDTH %d 0\nBBX %d %d 0 0\nBITMAP\n", i, i, size, size, size)
+		for j := 0; j < size; j++ {
+			buf.WriteString(strings.Repeat("FF", size/8) + "\n")
+		}
+		buf.WriteString("ENDCHAR\n")
+	}
+	buf.WriteString("ENDFONT\n")
+
+	f, err := ParseBDF(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages := map[image.Image]struct{}{}
+	for i := 0; i < n; i++ {
+		_, mask, maskp, _, ok := f.Glyph(fixed.Point26_6{}, rune(i))
+		if !ok {
+			t.Fatalf("Glyph(%d): got: false", i)
+		}
+		if h := mask.Bounds().Dy(); h > ebiten.MaxImageSize {
+			t.Errorf("the page height: got: %d, want: <= %d", h, ebiten.MaxImageSize)
+		}
+		if _, _, _, a := mask.At(maskp.X, maskp.Y).RGBA(); a == 0 {
+			t.Errorf("Glyph(%d): the glyph's pixel is not set", i)
+		}
+		pages[mask] = struct{}{}
+	}
+	if len(pages) < 2 {
+		t.Errorf("the number of pages: got: %d, want: >= 2", len(pages))
+	}
+	// The page images must be converted into Ebiten images without panicking.
+	if _, _, _, ok := f.GlyphImage(rune(n - 1)); !ok {
+		t.Errorf("GlyphImage(%d): got: false", n-1)
+	}
+}
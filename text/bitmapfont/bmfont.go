@@ -0,0 +1,375 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitmapfont
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+type bmChar struct {
+	id       int
+	x        int
+	y        int
+	width    int
+	height   int
+	xoffset  int
+	yoffset  int
+	xadvance int
+	page     int
+}
+
+type bmKerning struct {
+	first  int
+	second int
+	amount int
+}
+
+type bmFont struct {
+	lineHeight int
+	base       int
+	pages      map[int]string
+	chars      []bmChar
+	kernings   []bmKerning
+}
+
+// ParseBMFont parses an AngelCode BMFont file in the text, XML or binary format.
+//
+// openPage is called with each page's file name written in the font file, and must return the page image's content.
+// The page images are decoded by image.Decode, so the image formats must be registered,
+// e.g., by importing image/png.
+// The glyphs in the page images should be white with alpha so that they can be drawn in any colors.
+func ParseBMFont(r io.Reader, openPage func(name string) (io.ReadCloser, error)) (*Face, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var bm *bmFont
+	switch t := bytes.TrimSpace(data); {
+	case bytes.HasPrefix(data, []byte("BMF")):
+		bm, err = parseBMFontBinary(data)
+	case bytes.HasPrefix(t, []byte("<")):
+		bm, err = parseBMFontXML(data)
+	default:
+		bm, err = parseBMFontText(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFace()
+	f.lineHeight = bm.lineHeight
+	f.ascent = bm.base
+	f.descent = bm.lineHeight - bm.base
+
+	pageIndices := map[int]int{}
+	for id, name := range bm.pages {
+		rc, err := openPage(name)
+		if err != nil {
+			return nil, err
+		}
+		img, _, err := image.Decode(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("bitmapfont: decoding the page %q failed: %v", name, err)
+		}
+		pageIndices[id] = len(f.pages)
+		f.pages = append(f.pages, img)
+	}
+
+	for _, c := range bm.chars {
+		p, ok := pageIndices[c.page]
+		if !ok {
+			return nil, fmt.Errorf("bitmapfont: the page %d of the char %d is not found", c.page, c.id)
+		}
+		min := f.pages[p].Bounds().Min
+		f.glyphs[rune(c.id)] = &glyph{
+			page:    p,
+			src:     image.Rect(c.x, c.y, c.x+c.width, c.y+c.height).Add(min),
+			offset:  image.Pt(c.xoffset, c.yoffset-bm.base),
+			advance: c.xadvance,
+		}
+	}
+	for _, k := range bm.kernings {
+		f.kernings[kerningPair{rune(k.first), rune(k.second)}] = k.amount
+	}
+	return f, nil
+}
+
+// parseBMFontAttrs parses the attributes in a line of the text format like `char id=32 x=0`.
+func parseBMFontAttrs(line string) (string, map[string]string, error) {
+	line = strings.TrimSpace(line)
+	n := strings.IndexAny(line, " \t")
+	if n < 0 {
+		return line, map[string]string{}, nil
+	}
+	tag, rest := line[:n], line[n:]
+	attrs := map[string]string{}
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			return tag, attrs, nil
+		}
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			return "", nil, fmt.Errorf("bitmapfont: invalid attribute in %q", line)
+		}
+		key := rest[:eq]
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return "", nil, fmt.Errorf("bitmapfont: unterminated string in %q", line)
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		attrs[key] = value
+	}
+}
+
+// attrInts parses the integer attributes of the keys into the pointers of dsts.
+func attrInts(attrs map[string]string, keys []string, dsts ...*int) error {
+	for i, k := range keys {
+		v, ok := attrs[k]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("bitmapfont: invalid %s: %q", k, v)
+		}
+		*dsts[i] = n
+	}
+	return nil
+}
+
+func parseBMFontText(data []byte) (*bmFont, error) {
+	bm := &bmFont{
+		pages: map[int]string{},
+	}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+		tag, attrs, err := parseBMFontAttrs(s.Text())
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case "common":
+			if err := attrInts(attrs, []string{"lineHeight", "base"}, &bm.lineHeight, &bm.base); err != nil {
+				return nil, err
+			}
+		case "page":
+			var id int
+			if err := attrInts(attrs, []string{"id"}, &id); err != nil {
+				return nil, err
+			}
+			bm.pages[id] = attrs["file"]
+		case "char":
+			var c bmChar
+			if err := attrInts(attrs,
+				[]string{"id", "x", "y", "width", "height", "xoffset", "yoffset", "xadvance", "page"},
+				&c.id, &c.x, &c.y, &c.width, &c.height, &c.xoffset, &c.yoffset, &c.xadvance, &c.page); err != nil {
+				return nil, err
+			}
+			bm.chars = append(bm.chars, c)
+		case "kerning":
+			var k bmKerning
+			if err := attrInts(attrs, []string{"first", "second", "amount"}, &k.first, &k.second, &k.amount); err != nil {
+				return nil, err
+			}
+			bm.kernings = append(bm.kernings, k)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if bm.lineHeight == 0 {
+		return nil, errors.New("bitmapfont: the common line is not found")
+	}
+	return bm, nil
+}
+
+type xmlBMFont struct {
+	Common struct {
+		LineHeight int `xml:"lineHeight,attr"`
+		Base       int `xml:"base,attr"`
+	} `xml:"common"`
+	Pages []struct {
+		ID   int    `xml:"id,attr"`
+		File string `xml:"file,attr"`
+	} `xml:"pages>page"`
+	Chars []struct {
+		ID       int `xml:"id,attr"`
+		X        int `xml:"x,attr"`
+		Y        int `xml:"y,attr"`
+		Width    int `xml:"width,attr"`
+		Height   int `xml:"height,attr"`
+		XOffset  int `xml:"xoffset,attr"`
+		YOffset  int `xml:"yoffset,attr"`
+		XAdvance int `xml:"xadvance,attr"`
+		Page     int `xml:"page,attr"`
+	} `xml:"chars>char"`
+	Kernings []struct {
+		First  int `xml:"first,attr"`
+		Second int `xml:"second,attr"`
+		Amount int `xml:"amount,attr"`
+	} `xml:"kernings>kerning"`
+}
+
+func parseBMFontXML(data []byte) (*bmFont, error) {
+	var x xmlBMFont
+	if err := xml.Unmarshal(data, &x); err != nil {
+		return nil, fmt.Errorf("bitmapfont: parsing XML failed: %v", err)
+	}
+	if x.Common.LineHeight == 0 {
+		return nil, errors.New("bitmapfont: the common element is not found")
+	}
+	bm := &bmFont{
+		lineHeight: x.Common.LineHeight,
+		base:       x.Common.Base,
+		pages:      map[int]string{},
+	}
+	for _, p := range x.Pages {
+		bm.pages[p.ID] = p.File
+	}
+	for _, c := range x.Chars {
+		bm.chars = append(bm.chars, bmChar{
+			id:       c.ID,
+			x:        c.X,
+			y:        c.Y,
+			width:    c.Width,
+			height:   c.Height,
+			xoffset:  c.XOffset,
+			yoffset:  c.YOffset,
+			xadvance: c.XAdvance,
+			page:     c.Page,
+		})
+	}
+	for _, k := range x.Kernings {
+		bm.kernings = append(bm.kernings, bmKerning{
+			first:  k.First,
+			second: k.Second,
+			amount: k.Amount,
+		})
+	}
+	return bm, nil
+}
+
+// The block types of the binary format
+const (
+	bmBlockInfo     = 1
+	bmBlockCommon   = 2
+	bmBlockPages    = 3
+	bmBlockChars    = 4
+	bmBlockKernings = 5
+)
+
+func parseBMFontBinary(data []byte) (*bmFont, error) {
+	if len(data) < 4 || data[3] != 3 {
+		return nil, errors.New("bitmapfont: unsupported version of the binary format")
+	}
+	bm := &bmFont{
+		pages: map[int]string{},
+	}
+	le := binary.LittleEndian
+	data = data[4:]
+	for len(data) > 0 {
+		if len(data) < 5 {
+			return nil, errors.New("bitmapfont: unexpected end of the binary format")
+		}
+		t := data[0]
+		size := int(le.Uint32(data[1:5]))
+		data = data[5:]
+		if size > len(data) {
+			return nil, errors.New("bitmapfont: unexpected end of the binary format")
+		}
+		b := data[:size]
+		data = data[size:]
+
+		switch t {
+		case bmBlockCommon:
+			if len(b) < 4 {
+				return nil, errors.New("bitmapfont: invalid common block")
+			}
+			bm.lineHeight = int(le.Uint16(b[0:2]))
+			bm.base = int(le.Uint16(b[2:4]))
+		case bmBlockPages:
+			for id := 0; len(b) > 0; id++ {
+				n := bytes.IndexByte(b, 0)
+				if n < 0 {
+					return nil, errors.New("bitmapfont: invalid pages block")
+				}
+				bm.pages[id] = string(b[:n])
+				b = b[n+1:]
+			}
+		case bmBlockChars:
+			const charSize = 20
+			if len(b)%charSize != 0 {
+				return nil, errors.New("bitmapfont: invalid chars block")
+			}
+			for ; len(b) > 0; b = b[charSize:] {
+				bm.chars = append(bm.chars, bmChar{
+					id:       int(le.Uint32(b[0:4])),
+					x:        int(le.Uint16(b[4:6])),
+					y:        int(le.Uint16(b[6:8])),
+					width:    int(le.Uint16(b[8:10])),
+					height:   int(le.Uint16(b[10:12])),
+					xoffset:  int(int16(le.Uint16(b[12:14]))),
+					yoffset:  int(int16(le.Uint16(b[14:16]))),
+					xadvance: int(int16(le.Uint16(b[16:18]))),
+					page:     int(b[18]),
+				})
+			}
+		case bmBlockKernings:
+			const kerningSize = 10
+			if len(b)%kerningSize != 0 {
+				return nil, errors.New("bitmapfont: invalid kerning pairs block")
+			}
+			for ; len(b) > 0; b = b[kerningSize:] {
+				bm.kernings = append(bm.kernings, bmKerning{
+					first:  int(le.Uint32(b[0:4])),
+					second: int(le.Uint32(b[4:8])),
+					amount: int(int16(le.Uint16(b[8:10]))),
+				})
+			}
+		}
+	}
+	if bm.lineHeight == 0 {
+		return nil, errors.New("bitmapfont: the common block is not found")
+	}
+	return bm, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"golang.org/x/image/font"
+)
+
+var (
+	cacheHits      int
+	cacheMisses    int
+	cacheEvictions int
+)
+
+// CacheGlyphs renders the glyphs of the runes in the text into the glyph cache in advance.
+//
+// Drawing a text whose glyphs are not cached yet rasterizes the glyphs, and this might take a while.
+// CacheGlyphs is useful to avoid stutters at the first frame that draws the text, e.g., by calling this when a scene is loaded.
+//
+// For an SDF face, the signed distance fields are generated.
+// The dilated glyphs for outlines are not cached by CacheGlyphs.
+// Note that the glyph cache has a limited number of pages (see SetMaxGlyphCachePages),
+// and caching too many glyphs evicts the other glyphs.
+//
+// This function is concurrent-safe.
+func CacheGlyphs(face font.Face, text string) {
+	textM.Lock()
+	defer textM.Unlock()
+
+	n := now()
+	for _, r := range text {
+		switch f := resolveFace(face, r).(type) {
+		case *sdfFace:
+			getGlyphFromCache(fontFaceToFace(resolveFace(f.base, r)), r, true, 0, n)
+		case GlyphImager:
+			// The face prepares the glyph images by itself.
+			f.GlyphImage(r)
+		default:
+			getGlyphFromCache(fontFaceToFace(f), r, false, 0, n)
+		}
+	}
+}
+
+// GlyphCacheStats represents the statistics of the glyph cache.
+type GlyphCacheStats struct {
+	// Hits is the number of times that a glyph was found in the cache.
+	Hits int
+
+	// Misses is the number of times that a glyph was not found in the cache and was rendered.
+	Misses int
+
+	// Evictions is the number of the glyphs evicted from the cache to make room for other glyphs.
+	Evictions int
+
+	// Glyphs is the number of the glyphs in the cache.
+	Glyphs int
+
+	// Pages is the number of the pages of the cache.
+	Pages int
+}
+
+// ReadGlyphCacheStats returns the statistics of the glyph cache.
+//
+// Hits, Misses and Evictions are counted since the program starts or ResetGlyphCacheStats is called.
+// The lookups by CacheGlyphs are also counted.
+//
+// This function is concurrent-safe.
+func ReadGlyphCacheStats() GlyphCacheStats {
+	textM.Lock()
+	defer textM.Unlock()
+
+	s := GlyphCacheStats{
+		Hits:      cacheHits,
+		Misses:    cacheMisses,
+		Evictions: cacheEvictions,
+		Glyphs:    len(glyphs),
+	}
+	for _, ps := range pages {
+		s.Pages += len(ps)
+	}
+	return s
+}
+
+// ResetGlyphCacheStats resets Hits, Misses and Evictions of the statistics of the glyph cache.
+//
+// This function is concurrent-safe.
+func ResetGlyphCacheStats() {
+	textM.Lock()
+	defer textM.Unlock()
+
+	cacheHits = 0
+	cacheMisses = 0
+	cacheEvictions = 0
+}
+
+// DisposeFace releases the glyph cache of the face.
+//
+// DisposeFace is useful to release memory when the face is no longer used, e.g., when a scene is unloaded.
+// The pages that no longer have glyphs are disposed.
+// The face can still be used after DisposeFace, and then its glyphs are rendered again.
+//
+// For a fallback face and an SDF face, the glyph cache of the faces composing the face is also released.
+// DisposeFace doesn't close the face. Call the face's Close if needed.
+//
+// This function is concurrent-safe.
+func DisposeFace(face font.Face) {
+	textM.Lock()
+	defer textM.Unlock()
+
+	disposeFace(face)
+
+	for sdf, ps := range pages {
+		var alive []*page
+		for _, p := range ps {
+			if p.empty() {
+				p.image.Dispose()
+				continue
+			}
+			alive = append(alive, p)
+		}
+		pages[sdf] = alive
+	}
+}
+
+func disposeFace(face font.Face) {
+	switch f := face.(type) {
+	case *fallbackFace:
+		for _, ff := range f.faces {
+			disposeFace(ff)
+		}
+	case *sdfFace:
+		disposeFace(f.base)
+	}
+
+	key, ok := lookupFace(face)
+	if !ok {
+		return
+	}
+	for _, ps := range pages {
+		for _, p := range ps {
+			for _, s := range p.shelves {
+				gs := s.glyphs[:0]
+				for _, g := range s.glyphs {
+					if g.char.face == key {
+						delete(glyphs, g.char)
+						continue
+					}
+					gs = append(gs, g)
+				}
+				s.glyphs = gs
+				// Reuse the shelf if it becomes empty.
+				if len(s.glyphs) == 0 && s.x > 0 {
+					s.reset()
+				}
+			}
+		}
+	}
+	delete(faces, key)
+	delete(charBounds, key)
+}
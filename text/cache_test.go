@@ -0,0 +1,62 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/dave/ebiten"
+	. "github.com/dave/ebiten/text"
+)
+
+func TestGlyphCache(t *testing.T) {
+	face := newSquareFace(20, 26)
+	dst, _ := ebiten.NewImage(16, 16, ebiten.FilterNearest)
+
+	DisposeFace(face)
+	ResetGlyphCacheStats()
+	before := ReadGlyphCacheStats()
+
+	CacheGlyphs(face, "abca")
+	s := ReadGlyphCacheStats()
+	if got, want := s.Misses, 3; got != want {
+		t.Errorf("Misses after CacheGlyphs: got: %d, want: %d", got, want)
+	}
+	if got, want := s.Glyphs-before.Glyphs, 3; got != want {
+		t.Errorf("Glyphs after CacheGlyphs: got: %d, want: %d", got, want)
+	}
+
+	Draw(dst, "abc", face, 0, 0, color.White)
+	s = ReadGlyphCacheStats()
+	if got, want := s.Hits, 4; got != want {
+		t.Errorf("Hits after Draw: got: %d, want: %d", got, want)
+	}
+	if got, want := s.Misses, 3; got != want {
+		t.Errorf("Misses after Draw: got: %d, want: %d", got, want)
+	}
+
+	DisposeFace(face)
+	s = ReadGlyphCacheStats()
+	if got, want := s.Glyphs, before.Glyphs; got != want {
+		t.Errorf("Glyphs after DisposeFace: got: %d, want: %d", got, want)
+	}
+
+	Draw(dst, "abc", face, 0, 0, color.White)
+	s = ReadGlyphCacheStats()
+	if got, want := s.Misses, 6; got != want {
+		t.Errorf("Misses after DisposeFace: got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/font"
+
+	"github.com/dave/ebiten"
+)
+
+// EffectOptions represents the effects for DrawWithEffects.
+type EffectOptions struct {
+	// OutlineWidth is the width of the outline in pixels.
+	// If OutlineWidth is 0, no outline is drawn.
+	OutlineWidth int
+
+	// OutlineColor is the color of the outline.
+	OutlineColor color.Color
+
+	// ShadowOffset is the offset of the drop shadow in pixels.
+	// If ShadowOffset is (0, 0), no shadow is drawn.
+	ShadowOffset image.Point
+
+	// ShadowColor is the color of the drop shadow.
+	ShadowColor color.Color
+}
+
+// DrawWithEffects draws a given text on a given destination image dst with the options and the effects,
+// i.e., the outline and the drop shadow.
+//
+// The text is placed and colored in the same way as DrawWithOptions:
+// the options' GeoM, ColorM and CompositeMode are applied to the glyphs, the outline and the drop shadow.
+// For example, ColorM.Scale with an alpha value fades out the text with its effects.
+// The outline width and the shadow offset are in pixels before the options' GeoM is applied.
+//
+// The outline is drawn with the glyphs dilated by the outline width.
+// The dilated glyphs are generated when the glyphs are rendered, and are cached separately from the regular glyphs.
+// Then, drawing a text with an outline costs only twice as much as drawing the text without it.
+// The drop shadow has the same shape as the outlined glyphs, and is drawn under the outline and the glyphs.
+//
+// The outline is not drawn for the glyphs of faces that are not rendered into the glyph cache,
+// like SDF faces and GlyphImager faces. Use SDFFaceOptions for the outlines of SDF faces.
+//
+// options can be nil. In this case, the text is drawn in white with the dot position at (0, 0).
+// effects can be nil. In this case, DrawWithEffects is the same as DrawWithOptions.
+//
+// This function is concurrent-safe.
+func DrawWithEffects(dst *ebiten.Image, text string, face font.Face, options *ebiten.DrawImageOptions, effects *EffectOptions) {
+	if options == nil {
+		options = &ebiten.DrawImageOptions{}
+	}
+
+	textM.Lock()
+	drawText(dst, text, face, 0, 0, color.White, options, effects)
+	textM.Unlock()
+}
+
+// canDilate returns a boolean value indicating whether the glyphs of the face can be dilated.
+func canDilate(face font.Face) bool {
+	if _, ok := face.(*sdfFace); ok {
+		return false
+	}
+	if _, ok := face.(GlyphImager); ok {
+		return false
+	}
+	return true
+}
+
+// dilate dilates the glyph image in the region (0, 0)-(w, h) of img by radius pixels.
+//
+// The alpha value of a pixel becomes the maximum of the alpha values of the pixels within the radius,
+// and the edge of the dilated glyph is anti-aliased.
+// The result is stored as white.
+func dilate(img *image.RGBA, w, h int, radius int) {
+	b := img.Bounds()
+	if w > b.Dx() {
+		w = b.Dx()
+	}
+	if h > b.Dy() {
+		h = b.Dy()
+	}
+	src := make([]uint8, w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			src[j*w+i] = img.Pix[img.PixOffset(b.Min.X+i, b.Min.Y+j)+3]
+		}
+	}
+
+	// weights is the coverage of the disc at each offset.
+	r := radius + 1
+	n := 2*r + 1
+	weights := make([]float64, n*n)
+	for dj := -r; dj <= r; dj++ {
+		for di := -r; di <= r; di++ {
+			d := math.Sqrt(float64(di*di + dj*dj))
+			c := float64(radius) + 1 - d
+			if c > 1 {
+				c = 1
+			}
+			if c < 0 {
+				c = 0
+			}
+			weights[(dj+r)*n+(di+r)] = c
+		}
+	}
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			var a float64
+			for dj := -r; dj <= r; dj++ {
+				jj := j + dj
+				if jj < 0 || jj >= h {
+					continue
+				}
+				for di := -r; di <= r; di++ {
+					ii := i + di
+					if ii < 0 || ii >= w {
+						continue
+					}
+					s := src[jj*w+ii]
+					if s == 0 {
+						continue
+					}
+					if v := float64(s) * weights[(dj+r)*n+(di+r)]; a < v {
+						a = v
+					}
+				}
+			}
+			v := uint8(a)
+			p := img.PixOffset(b.Min.X+i, b.Min.Y+j)
+			img.Pix[p] = v
+			img.Pix[p+1] = v
+			img.Pix[p+2] = v
+			img.Pix[p+3] = v
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font"
+
+	"github.com/dave/ebiten"
+	. "github.com/dave/ebiten/text"
+)
+
+func TestDrawWithEffects(t *testing.T) {
+	dst, _ := ebiten.NewImage(64, 64, ebiten.FilterNearest)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(0, 16)
+	outline := &EffectOptions{
+		OutlineWidth: 2,
+		OutlineColor: color.Black,
+	}
+	shadow := &EffectOptions{
+		ShadowOffset: image.Pt(1, 1),
+		ShadowColor:  color.Gray{0x80},
+	}
+	both := &EffectOptions{
+		OutlineWidth: 2,
+		OutlineColor: color.Black,
+		ShadowOffset: image.Pt(1, 1),
+		ShadowColor:  color.Gray{0x80},
+	}
+
+	face := newSquareFace(10, 26)
+	sdf := NewSDFFace(newSquareFace(10, 26), nil)
+	DisposeFace(face)
+	DisposeFace(sdf)
+	ResetGlyphCacheStats()
+
+	cases := []struct {
+		Name    string
+		Face    font.Face
+		Effects *EffectOptions
+		// Misses is the number of the glyphs newly cached by the drawing.
+		Misses int
+	}{
+		{"no effects", face, nil, 3},
+		// The dilated glyphs are cached separately from the regular glyphs.
+		{"outline", face, outline, 3},
+		// The shadows without outlines are drawn with the regular glyphs.
+		{"shadow", face, shadow, 0},
+		// The shadows with outlines are drawn with the dilated glyphs.
+		{"outline and shadow", face, both, 0},
+		{"sdf", sdf, nil, 3},
+		// The glyphs of SDF faces are not dilated.
+		{"sdf with effects", sdf, both, 0},
+		{"fallback with effects", NewFallbackFace(sdf, face), both, 0},
+	}
+	before := 0
+	for _, c := range cases {
+		DrawWithEffects(dst, "ab\nc", c.Face, op, c.Effects)
+		misses := ReadGlyphCacheStats().Misses
+		if got := misses - before; got != c.Misses {
+			t.Errorf("%s: cache misses: got: %d, want: %d", c.Name, got, c.Misses)
+		}
+		before = misses
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// glyphCoverer is implemented by a face that can report whether it has a glyph for a rune.
+type glyphCoverer interface {
+	HasGlyph(r rune) bool
+}
+
+type coverageFace struct {
+	font.Face
+	hasGlyph func(r rune) bool
+}
+
+func (c *coverageFace) HasGlyph(r rune) bool {
+	return c.hasGlyph(r)
+}
+
+// FaceWithCoverage returns a face that behaves as face, but reports to a fallback face
+// that it has a glyph for a rune only when hasGlyph returns true for the rune.
+//
+// FaceWithCoverage is useful for a face that doesn't report missing glyphs by itself.
+// For example, a face of github.com/golang/freetype/truetype can be used with
+// the function that checks that the font's Index for the rune is not 0.
+func FaceWithCoverage(face font.Face, hasGlyph func(r rune) bool) font.Face {
+	return &coverageFace{
+		Face:     face,
+		hasGlyph: hasGlyph,
+	}
+}
+
+type fallbackFace struct {
+	faces []font.Face
+
+	// runeToFace is the cache of the resolving faces.
+	runeToFace map[rune]font.Face
+
+	// m guards runeToFace since the exported methods of font.Face might be called concurrently.
+	m sync.Mutex
+}
+
+// NewFallbackFace returns a face that composes the faces with ordered fallback.
+//
+// Each rune is drawn with the first face that has a glyph for the rune.
+// Whether a face has a glyph is determined by the ok value of the face's GlyphAdvance,
+// or by the function given to FaceWithCoverage.
+// If no face has a glyph for the rune, the first face is used.
+//
+// The kerning applies only between two runes resolved to the same face.
+// The metrics are the first face's metrics.
+// Close closes all the faces.
+//
+// NewFallbackFace panics if no face is given.
+func NewFallbackFace(faces ...font.Face) font.Face {
+	if len(faces) == 0 {
+		panic("text: no face is given to NewFallbackFace")
+	}
+	return &fallbackFace{
+		faces:      append([]font.Face{}, faces...),
+		runeToFace: map[rune]font.Face{},
+	}
+}
+
+func hasGlyph(face font.Face, r rune) bool {
+	if c, ok := face.(glyphCoverer); ok {
+		return c.HasGlyph(r)
+	}
+	_, ok := face.GlyphAdvance(r)
+	return ok
+}
+
+// resolve returns the face to draw the rune r.
+func (f *fallbackFace) resolve(r rune) font.Face {
+	f.m.Lock()
+	face, ok := f.runeToFace[r]
+	f.m.Unlock()
+	if ok {
+		return face
+	}
+	face = f.faces[0]
+	for _, ff := range f.faces {
+		if hasGlyph(ff, r) {
+			face = ff
+			break
+		}
+	}
+	f.m.Lock()
+	f.runeToFace[r] = face
+	f.m.Unlock()
+	return face
+}
+
+// HasGlyph implements glyphCoverer so that a fallback face can be nested in another fallback face.
+func (f *fallbackFace) HasGlyph(r rune) bool {
+	for _, ff := range f.faces {
+		if hasGlyph(ff, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fallbackFace) Close() error {
+	var err error
+	for _, ff := range f.faces {
+		if e := ff.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	return f.resolve(r).Glyph(dot, r)
+}
+
+func (f *fallbackFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	return f.resolve(r).GlyphBounds(r)
+}
+
+func (f *fallbackFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	return f.resolve(r).GlyphAdvance(r)
+}
+
+func (f *fallbackFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	face := f.resolve(r0)
+	if face != f.resolve(r1) {
+		return 0
+	}
+	return face.Kern(r0, r1)
+}
+
+func (f *fallbackFace) Metrics() font.Metrics {
+	return f.faces[0].Metrics()
+}
+
+// resolveFace returns the face that actually draws the rune r.
+func resolveFace(face font.Face, r rune) font.Face {
+	for {
+		f, ok := face.(*fallbackFace)
+		if !ok {
+			return face
+		}
+		face = f.resolve(r)
+	}
+}
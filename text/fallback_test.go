@@ -0,0 +1,74 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"sync"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/inconsolata"
+	"golang.org/x/image/math/fixed"
+
+	. "github.com/dave/ebiten/text"
+)
+
+func TestFallbackFace(t *testing.T) {
+	am := FaceWithCoverage(inconsolata.Regular8x16, func(r rune) bool {
+		return 'a' <= r && r <= 'm'
+	})
+	f := NewFallbackFace(am, basicfont.Face7x13)
+	notFound, _ := inconsolata.Regular8x16.GlyphAdvance('あ')
+
+	cases := []struct {
+		Rune    rune
+		Advance fixed.Int26_6
+	}{
+		{'a', fixed.I(8)},
+		{'z', fixed.I(7)},
+		// No face has the glyph. The first face is used.
+		{'あ', notFound},
+	}
+	for _, c := range cases {
+		if got, _ := f.GlyphAdvance(c.Rune); got != c.Advance {
+			t.Errorf("GlyphAdvance(%q): got: %v, want: %v", c.Rune, got, c.Advance)
+		}
+	}
+	if got, want := Advance(f, "az\nab"), fixed.I(16); got != want {
+		t.Errorf("Advance: got: %v, want: %v", got, want)
+	}
+	if got, want := f.Metrics(), inconsolata.Regular8x16.Metrics(); got != want {
+		t.Errorf("Metrics(): got: %v, want: %v", got, want)
+	}
+}
+
+func TestFallbackFaceConcurrent(t *testing.T) {
+	f := NewFallbackFace(inconsolata.Regular8x16, basicfont.Face7x13)
+	want, _ := inconsolata.Regular8x16.GlyphAdvance('a')
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := 'a'; r <= 'z'; r++ {
+				if got, _ := f.GlyphAdvance(r); got != want {
+					t.Errorf("GlyphAdvance(%q): got: %v, want: %v", r, got, want)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,450 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"image/color"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+)
+
+// Align represents a horizontal alignment of lines.
+type Align int
+
+// Aligns
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+
+	// AlignJustify stretches the spaces of a line to fit the width.
+	// The last line of a paragraph is aligned to the left.
+	AlignJustify
+)
+
+// LayoutOptions represents options for NewLayout and NewLayoutFromSpans.
+type LayoutOptions struct {
+	// MaxWidth is the maximum width of a line in pixels.
+	// A line longer than MaxWidth is wrapped at a line break opportunity.
+	// If a word is longer than MaxWidth, the word is broken at any position.
+	// If MaxWidth is 0 or less, lines are not wrapped.
+	MaxWidth int
+
+	// LineSpacing is the distance between two baselines relative to the height of the face's metrics.
+	// When a line has multiple faces or inline images, the tallest one determines the distance from the previous line.
+	// If LineSpacing is 0, 1 is used.
+	LineSpacing float64
+
+	// Align is the horizontal alignment of the lines.
+	// The lines are aligned in the width of MaxWidth, or the width of the longest line if MaxWidth is not specified.
+	Align Align
+}
+
+// Span represents a part of a text with a style.
+type Span struct {
+	// Text is the text of the span.
+	Text string
+
+	// Face is the font face of the span.
+	// If Face is nil, the face of the previous span is used.
+	Face font.Face
+
+	// Color is the color of the span.
+	// If Color is nil, the color given to Layout.Draw is used.
+	Color color.Color
+
+	// Image is the inline image of the span.
+	// If Image is not nil, Text is ignored, and the image is placed as one glyph whose bottom is on the baseline.
+	Image *ebiten.Image
+}
+
+// Glyph represents a positioned glyph.
+type Glyph struct {
+	// Rune is the rune of the glyph.
+	// For an inline image, Rune is U+FFFC (object replacement character).
+	Rune rune
+
+	// X and Y are the dot position of the glyph relative to the layout's origin.
+	X fixed.Int26_6
+	Y fixed.Int26_6
+
+	// Face is the font face of the glyph.
+	// Face is nil for an inline image.
+	Face font.Face
+
+	// Color is the color of the glyph.
+	// If Color is nil, the color given to Layout.Draw is used.
+	Color color.Color
+
+	// Image is the inline image.
+	Image *ebiten.Image
+}
+
+// objectReplacementChar is the rune for inline images.
+const objectReplacementChar = '\ufffc'
+
+// advance returns the advance of the glyph.
+//
+// advance must be called with textM locked.
+func (g *Glyph) advance() fixed.Int26_6 {
+	if g.Image != nil {
+		w, _ := g.Image.Size()
+		return fixed.I(w)
+	}
+	a, _ := g.Face.GlyphAdvance(g.Rune)
+	return a
+}
+
+// bounds returns the bounds of the glyph relative to the glyph's dot position.
+//
+// bounds must be called with textM locked.
+func (g *Glyph) bounds() fixed.Rectangle26_6 {
+	if g.Image != nil {
+		w, h := g.Image.Size()
+		return fixed.R(0, -h, w, 0)
+	}
+	b, _, _ := g.Face.GlyphBounds(g.Rune)
+	return b
+}
+
+// height returns the height that the glyph requires for its line.
+//
+// height must be called with textM locked.
+func (g *Glyph) height() fixed.Int26_6 {
+	if g.Image != nil {
+		_, h := g.Image.Size()
+		return fixed.I(h)
+	}
+	return lineHeight(g.Face)
+}
+
+// Line represents a line of a layout.
+type Line struct {
+	// Glyphs is the positioned glyphs of the line.
+	// Glyphs includes the spaces at the end of the line.
+	Glyphs []Glyph
+
+	// X is the start position of the line after the alignment.
+	X fixed.Int26_6
+
+	// Y is the position of the baseline.
+	Y fixed.Int26_6
+
+	// Width is the advance width of the line without the spaces at the end of the line.
+	Width fixed.Int26_6
+}
+
+// Layout represents a text laid out in lines.
+//
+// The layout's origin is the dot position of the first line when the line is aligned to the left,
+// as well as the position given to Draw.
+type Layout struct {
+	// Lines is the lines of the layout.
+	Lines []Line
+}
+
+type layoutRune struct {
+	glyph   Glyph
+	advance fixed.Int26_6
+}
+
+type paragraph struct {
+	glyphs []Glyph
+
+	// face is the face at the start of the paragraph, which determines the height of an empty line.
+	face font.Face
+}
+
+// NewLayout lays out the text with the face.
+//
+// A newline ('\n') in the text always starts a new paragraph.
+// If options is nil, the default options are used.
+//
+// This function is concurrent-safe.
+func NewLayout(text string, face font.Face, options *LayoutOptions) *Layout {
+	return NewLayoutFromSpans([]Span{{Text: text, Face: face}}, options)
+}
+
+// NewLayoutFromSpans lays out the styled spans.
+//
+// The first span must have a face unless the first span is an inline image.
+// A newline ('\n') in the spans always starts a new paragraph.
+// If options is nil, the default options are used.
+//
+// This function is concurrent-safe.
+func NewLayoutFromSpans(spans []Span, options *LayoutOptions) *Layout {
+	if options == nil {
+		options = &LayoutOptions{}
+	}
+
+	textM.Lock()
+	defer textM.Unlock()
+
+	maxWidth := fixed.I(options.MaxWidth)
+	spacing := options.LineSpacing
+	if spacing == 0 {
+		spacing = 1
+	}
+
+	l := &Layout{}
+	var lastInParagraph []bool
+	var y fixed.Int26_6
+	for _, p := range splitParagraphs(spans) {
+		lines := wrap(p.glyphs, maxWidth)
+		for i, line := range lines {
+			var lh fixed.Int26_6
+			if p.face != nil {
+				lh = lineHeight(p.face)
+			}
+			for _, r := range line {
+				if h := r.glyph.height(); lh < h {
+					lh = h
+				}
+			}
+			if len(l.Lines) > 0 {
+				y += fixed.Int26_6(float64(lh) * spacing)
+			}
+
+			glyphs := make([]Glyph, len(line))
+			var width fixed.Int26_6
+			for j, r := range line {
+				glyphs[j] = r.glyph
+				glyphs[j].Y = y
+				if !isBreakSpace(r.glyph.Rune) {
+					width = r.glyph.X + r.advance
+				}
+			}
+			l.Lines = append(l.Lines, Line{
+				Glyphs: glyphs,
+				Y:      y,
+				Width:  width,
+			})
+			lastInParagraph = append(lastInParagraph, i == len(lines)-1)
+		}
+	}
+
+	w := maxWidth
+	if w <= 0 {
+		w = 0
+		for _, line := range l.Lines {
+			if w < line.Width {
+				w = line.Width
+			}
+		}
+	}
+	for i := range l.Lines {
+		l.Lines[i].align(options.Align, w, lastInParagraph[i])
+	}
+	return l
+}
+
+// splitParagraphs converts the spans into glyphs and splits them by newlines.
+func splitParagraphs(spans []Span) []paragraph {
+	var face font.Face
+	for _, s := range spans {
+		if s.Image == nil && s.Face != nil {
+			face = s.Face
+			break
+		}
+	}
+
+	ps := []paragraph{{face: face}}
+	for _, s := range spans {
+		if s.Face != nil {
+			face = s.Face
+		}
+		p := &ps[len(ps)-1]
+		if s.Image != nil {
+			p.glyphs = append(p.glyphs, Glyph{
+				Rune:  objectReplacementChar,
+				Color: s.Color,
+				Image: s.Image,
+			})
+			continue
+		}
+		for _, r := range s.Text {
+			if r == '\n' {
+				// Remove '\r' of "\r\n".
+				if n := len(p.glyphs); n > 0 && p.glyphs[n-1].Rune == '\r' && p.glyphs[n-1].Image == nil {
+					p.glyphs = p.glyphs[:n-1]
+				}
+				ps = append(ps, paragraph{face: face})
+				p = &ps[len(ps)-1]
+				continue
+			}
+			p.glyphs = append(p.glyphs, Glyph{
+				Rune:  r,
+				Face:  face,
+				Color: s.Color,
+			})
+		}
+	}
+	return ps
+}
+
+// wrap breaks the glyphs of a paragraph into lines whose widths are at most maxWidth.
+// The positions of the glyphs are relative to the start of their lines.
+//
+// wrap must be called with textM locked.
+func wrap(glyphs []Glyph, maxWidth fixed.Int26_6) [][]layoutRune {
+	runes := make([]rune, len(glyphs))
+	for i, g := range glyphs {
+		runes[i] = g.Rune
+	}
+
+	var lines [][]layoutRune
+	start := 0
+	for {
+		var line []layoutRune
+		var x fixed.Int26_6
+		lastBreak := -1
+		end := start
+		for ; end < len(glyphs); end++ {
+			g := glyphs[end]
+			if end > start {
+				if prev := glyphs[end-1]; prev.Image == nil && g.Image == nil && prev.Face == g.Face {
+					x += g.Face.Kern(prev.Rune, g.Rune)
+				}
+			}
+			if end > start && canBreakBefore(runes, end) {
+				lastBreak = end
+			}
+			a := g.advance()
+			// Spaces can exceed the width since they hang at the end of the line.
+			if maxWidth > 0 && end > start && !isBreakSpace(g.Rune) && x+a > maxWidth {
+				break
+			}
+			g.X = x
+			line = append(line, layoutRune{
+				glyph:   g,
+				advance: a,
+			})
+			x += a
+		}
+		if end < len(glyphs) && lastBreak > start {
+			line = line[:lastBreak-start]
+			end = lastBreak
+		}
+		lines = append(lines, line)
+		if end >= len(glyphs) {
+			return lines
+		}
+		start = end
+	}
+}
+
+func (l *Line) align(align Align, width fixed.Int26_6, lastInParagraph bool) {
+	switch align {
+	case AlignCenter:
+		l.X = (width - l.Width) / 2
+	case AlignRight:
+		l.X = width - l.Width
+	case AlignJustify:
+		if lastInParagraph {
+			break
+		}
+		// Count the spaces between words.
+		n := 0
+		trailing := true
+		for i := len(l.Glyphs) - 1; i >= 0; i-- {
+			if !isBreakSpace(l.Glyphs[i].Rune) {
+				trailing = false
+				continue
+			}
+			if !trailing {
+				n++
+			}
+		}
+		if n == 0 || width <= l.Width {
+			break
+		}
+		extra := width - l.Width
+		k := 0
+		for i := range l.Glyphs {
+			l.Glyphs[i].X += extra * fixed.Int26_6(k) / fixed.Int26_6(n)
+			if isBreakSpace(l.Glyphs[i].Rune) && k < n {
+				k++
+			}
+		}
+		l.Width = width
+	}
+	for i := range l.Glyphs {
+		l.Glyphs[i].X += l.X
+	}
+}
+
+// Bounds returns the bounding box of the glyphs of the layout relative to the layout's origin.
+//
+// This function is concurrent-safe.
+func (l *Layout) Bounds() image.Rectangle {
+	textM.Lock()
+
+	var bounds fixed.Rectangle26_6
+	for _, line := range l.Lines {
+		for _, g := range line.Glyphs {
+			bounds = bounds.Union(g.bounds().Add(fixed.Point26_6{X: g.X, Y: g.Y}))
+		}
+	}
+
+	textM.Unlock()
+
+	return image.Rect(bounds.Min.X.Floor(), bounds.Min.Y.Floor(), bounds.Max.X.Ceil(), bounds.Max.Y.Ceil())
+}
+
+// Draw draws the layout on dst with the layout's origin at (x, y).
+//
+// clr is the color for the glyphs without their own colors.
+// Inline images are drawn as they are regardless of the colors.
+//
+// This function is concurrent-safe.
+func (l *Layout) Draw(dst *ebiten.Image, x, y int, clr color.Color) {
+	textM.Lock()
+
+	var glyphs []drawnGlyph
+	for _, line := range l.Lines {
+		for _, g := range line.Glyphs {
+			gx, gy := fixed.I(x)+g.X, fixed.I(y)+g.Y
+			if g.Image != nil {
+				// Inline images are drawn in the order of the glyphs.
+				drawGlyphs(dst, glyphs, nil, nil)
+				glyphs = glyphs[:0]
+
+				_, h := g.Image.Size()
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(fixed26_6ToFloat64(gx), fixed26_6ToFloat64(gy)-float64(h))
+				dst.DrawImage(g.Image, op)
+				continue
+			}
+			c := g.Color
+			if c == nil {
+				c = clr
+			}
+			glyphs = append(glyphs, drawnGlyph{
+				face: fontFaceToFace(g.Face),
+				rune: g.Rune,
+				x:    gx,
+				y:    gy,
+				clr:  c,
+			})
+		}
+	}
+	drawGlyphs(dst, glyphs, nil, nil)
+
+	textM.Unlock()
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	. "github.com/dave/ebiten/text"
+)
+
+func lineStrings(l *Layout) []string {
+	var strs []string
+	for _, line := range l.Lines {
+		var rs []rune
+		for _, g := range line.Glyphs {
+			rs = append(rs, g.Rune)
+		}
+		strs = append(strs, string(rs))
+	}
+	return strs
+}
+
+func TestLayoutWrap(t *testing.T) {
+	f := basicfont.Face7x13
+	cases := []struct {
+		Text     string
+		MaxWidth int
+		Want     []string
+	}{
+		{"hello world foo", 80, []string{"hello world ", "foo"}},
+		{"hello world foo", 0, []string{"hello world foo"}},
+		{"abcdefghij", 21, []string{"abc", "def", "ghi", "j"}},
+		{"well-known", 42, []string{"well-", "known"}},
+		{"あいうえお", 21, []string{"あいう", "えお"}},
+		{"あいう。", 21, []string{"あい", "う。"}},
+		{"「あい」", 21, []string{"「あ", "い」"}},
+		{"a\r\nb\n\nc", 0, []string{"a", "b", "", "c"}},
+	}
+	for _, c := range cases {
+		l := NewLayout(c.Text, f, &LayoutOptions{MaxWidth: c.MaxWidth})
+		if got := lineStrings(l); !reflect.DeepEqual(got, c.Want) {
+			t.Errorf("NewLayout(%q, MaxWidth: %d): got: %q, want: %q", c.Text, c.MaxWidth, got, c.Want)
+		}
+	}
+}
+
+func TestLayoutAlign(t *testing.T) {
+	f := basicfont.Face7x13
+	cases := []struct {
+		Align Align
+		X     []fixed.Int26_6
+	}{
+		{AlignLeft, []fixed.Int26_6{0, 0}},
+		{AlignCenter, []fixed.Int26_6{fixed.I(3) / 2, fixed.I(59) / 2}},
+		{AlignRight, []fixed.Int26_6{fixed.I(3), fixed.I(59)}},
+		{AlignJustify, []fixed.Int26_6{0, 0}},
+	}
+	for _, c := range cases {
+		l := NewLayout("hello world foo", f, &LayoutOptions{
+			MaxWidth:    80,
+			LineSpacing: 2,
+			Align:       c.Align,
+		})
+		for i, line := range l.Lines {
+			if line.X != c.X[i] {
+				t.Errorf("align: %d, line %d: X: got: %v, want: %v", c.Align, i, line.X, c.X[i])
+			}
+			if got, want := line.Y, fixed.I(26*i); got != want {
+				t.Errorf("align: %d, line %d: Y: got: %v, want: %v", c.Align, i, got, want)
+			}
+		}
+		// The second word of the first line.
+		g := l.Lines[0].Glyphs[6]
+		want := c.X[0] + fixed.I(42)
+		if c.Align == AlignJustify {
+			want = fixed.I(45)
+		}
+		if g.X != want {
+			t.Errorf("align: %d: the glyph %q: X: got: %v, want: %v", c.Align, g.Rune, g.X, want)
+		}
+	}
+}
+
+func TestLayoutBounds(t *testing.T) {
+	l := NewLayout("ab\nc", basicfont.Face7x13, nil)
+	if got, want := l.Bounds(), BoundString(basicfont.Face7x13, "ab\nc"); got != want {
+		t.Errorf("Bounds(): got: %v, want: %v", got, want)
+	}
+}
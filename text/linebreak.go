@@ -0,0 +1,104 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"unicode"
+)
+
+// The line breaking here is a simplified version of the Unicode line breaking algorithm (UAX #14).
+// It covers the breaks after spaces and hyphens, the breaks around ideographs,
+// and the prohibited breaks around punctuations.
+
+func isBreakSpace(r rune) bool {
+	switch r {
+	case '\u00a0', '\u2007', '\u202f':
+		// No-break spaces
+		return false
+	case '\t':
+		return true
+	}
+	return unicode.Is(unicode.Zs, r)
+}
+
+func isHyphen(r rune) bool {
+	switch r {
+	case '-', '\u00ad', '‐', '‒', '–':
+		return true
+	}
+	return false
+}
+
+func isIdeographic(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= '\u3000' && r <= '\u303f') || (r >= '\uff00' && r <= '\uffef')
+}
+
+// isOpening reports whether r is an opening punctuation, which prohibits a break after it.
+func isOpening(r rune) bool {
+	switch r {
+	case '(', '[', '{', '«', '‘', '“', '〈', '《', '「', '『', '【', '〔', '〖', '〘', '〚', '（', '［', '｛', '｢':
+		return true
+	}
+	return unicode.Is(unicode.Ps, r)
+}
+
+// isClosing reports whether r is a closing punctuation or a non-starter, which prohibits a break before it.
+func isClosing(r rune) bool {
+	switch r {
+	case ')', ']', '}', '!', '?', ',', '.', ':', ';', '/', '»', '’', '”',
+		'、', '。', '々', '〉', '》', '」', '』', '】', '〕', '〗', '〙', '〛',
+		'ぁ', 'ぃ', 'ぅ', 'ぇ', 'ぉ', 'っ', 'ゃ', 'ゅ', 'ょ', 'ゎ',
+		'ァ', 'ィ', 'ゥ', 'ェ', 'ォ', 'ッ', 'ャ', 'ュ', 'ョ', 'ヮ', '・', 'ー',
+		'！', '）', '，', '．', '：', '；', '？', '］', '｝', '｣':
+		return true
+	}
+	return unicode.In(r, unicode.Pe, unicode.Pf)
+}
+
+// canBreakBefore reports whether a line can be broken between runes[i-1] and runes[i].
+// A line is never broken before the first rune.
+func canBreakBefore(runes []rune, i int) bool {
+	if i <= 0 || i >= len(runes) {
+		return false
+	}
+	prev, r := runes[i-1], runes[i]
+	if prev == '\u200b' {
+		// Zero width space
+		return true
+	}
+	if r == '\u200b' || r == '\u2060' || prev == '\u2060' {
+		// Word joiner prohibits breaks.
+		return false
+	}
+	if isBreakSpace(r) {
+		// Spaces don't start a line. They hang at the end of the previous line instead.
+		return false
+	}
+	if isClosing(r) || isOpening(prev) {
+		return false
+	}
+	if isBreakSpace(prev) {
+		return true
+	}
+	if isHyphen(prev) {
+		// Break after a hyphen only between words, e.g., not in "-1".
+		return i >= 2 && unicode.IsLetter(runes[i-2]) && unicode.IsLetter(r)
+	}
+	if isIdeographic(prev) || isIdeographic(r) || prev == objectReplacementChar || r == objectReplacementChar {
+		return true
+	}
+	return false
+}
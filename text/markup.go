@@ -0,0 +1,174 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/font"
+
+	"github.com/dave/ebiten"
+)
+
+// MarkupOptions represents the named resources that a markup refers to.
+type MarkupOptions struct {
+	// Faces is the font faces referred by the face tags.
+	Faces map[string]font.Face
+
+	// Images is the images referred by the image tags.
+	Images map[string]*ebiten.Image
+}
+
+// ParseMarkup parses the markup text into styled spans.
+//
+// face is the font face for the text outside of the face tags.
+// The color of the text outside of the color tags is nil, which means the color given to Layout.Draw.
+//
+// The markup has the following tags:
+//
+//   - [color=#rrggbb] or [color=#rrggbbaa] changes the color until [/color].
+//   - [face=name] changes the font face to options.Faces[name] until [/face].
+//   - [image=name] puts options.Images[name] as an inline image.
+//   - [[ is a literal '['.
+//
+// The tags can be nested, and a closing tag must match the innermost opening tag.
+// For example, "Press [image=a] to [color=#ff0000]jump[/color]" has an inline image and a red word.
+//
+// ParseMarkup returns an error when the markup has an unknown tag, an unknown name,
+// an invalid color, a mismatched closing tag or an unclosed tag.
+func ParseMarkup(markup string, face font.Face, options *MarkupOptions) ([]Span, error) {
+	if options == nil {
+		options = &MarkupOptions{}
+	}
+
+	type style struct {
+		tag   string
+		face  font.Face
+		color color.Color
+	}
+	stack := []style{{face: face}}
+
+	var spans []Span
+	var buf []byte
+	flush := func() {
+		if len(buf) == 0 {
+			return
+		}
+		s := stack[len(stack)-1]
+		spans = append(spans, Span{
+			Text:  string(buf),
+			Face:  s.face,
+			Color: s.color,
+		})
+		buf = nil
+	}
+
+	for i := 0; i < len(markup); i++ {
+		c := markup[i]
+		if c != '[' {
+			buf = append(buf, c)
+			continue
+		}
+		if i+1 < len(markup) && markup[i+1] == '[' {
+			buf = append(buf, '[')
+			i++
+			continue
+		}
+		end := strings.IndexByte(markup[i:], ']')
+		if end < 0 {
+			return nil, errors.New("text: unterminated tag")
+		}
+		tag := markup[i+1 : i+end]
+		i += end
+
+		if strings.HasPrefix(tag, "/") {
+			name := tag[1:]
+			if len(stack) == 1 || stack[len(stack)-1].tag != name {
+				return nil, fmt.Errorf("text: mismatched closing tag [%s]", tag)
+			}
+			flush()
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		name, value := tag, ""
+		if n := strings.IndexByte(tag, '='); n >= 0 {
+			name, value = tag[:n], tag[n+1:]
+		}
+		s := stack[len(stack)-1]
+		switch name {
+		case "color":
+			clr, err := parseColor(value)
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			s.tag = name
+			s.color = clr
+			stack = append(stack, s)
+		case "face":
+			f, ok := options.Faces[value]
+			if !ok {
+				return nil, fmt.Errorf("text: unknown face %q", value)
+			}
+			flush()
+			s.tag = name
+			s.face = f
+			stack = append(stack, s)
+		case "image":
+			img, ok := options.Images[value]
+			if !ok {
+				return nil, fmt.Errorf("text: unknown image %q", value)
+			}
+			flush()
+			spans = append(spans, Span{
+				Face:  s.face,
+				Color: s.color,
+				Image: img,
+			})
+		default:
+			return nil, fmt.Errorf("text: unknown tag [%s]", tag)
+		}
+	}
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("text: unclosed tag [%s]", stack[len(stack)-1].tag)
+	}
+	flush()
+	return spans, nil
+}
+
+// parseColor parses a color in the form of #rrggbb or #rrggbbaa.
+func parseColor(str string) (color.Color, error) {
+	if !strings.HasPrefix(str, "#") || (len(str) != 7 && len(str) != 9) {
+		return nil, fmt.Errorf("text: invalid color %q", str)
+	}
+	v, err := strconv.ParseUint(str[1:], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("text: invalid color %q", str)
+	}
+	if len(str) == 7 {
+		v = v<<8 | 0xff
+	}
+	return color.NRGBA{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: uint8(v),
+	}, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/inconsolata"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+	. "github.com/dave/ebiten/text"
+)
+
+func TestParseMarkup(t *testing.T) {
+	f := basicfont.Face7x13
+	bold := inconsolata.Bold8x16
+	img, _ := ebiten.NewImage(10, 16, ebiten.FilterNearest)
+	red := color.NRGBA{0xff, 0, 0, 0xff}
+	options := &MarkupOptions{
+		Faces:  map[string]font.Face{"bold": bold},
+		Images: map[string]*ebiten.Image{"a": img},
+	}
+	got, err := ParseMarkup("Press [image=a] to [color=#ff0000]jump [face=bold]now[/face][/color] [[ok]", f, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Span{
+		{Text: "Press ", Face: f},
+		{Face: f, Image: img},
+		{Text: " to ", Face: f},
+		{Text: "jump ", Face: f, Color: red},
+		{Text: "now", Face: bold, Color: red},
+		{Text: " [ok]", Face: f},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	for _, markup := range []string{
+		"[foo]",
+		"[color=red]",
+		"[color=#ff0000]",
+		"[color=#ff0000]a[/face]",
+		"[face=italic]",
+		"[image=b]",
+		"[color",
+	} {
+		if _, err := ParseMarkup(markup, f, options); err == nil {
+			t.Errorf("ParseMarkup(%q) must return an error", markup)
+		}
+	}
+}
+
+func TestLayoutFromSpans(t *testing.T) {
+	f := basicfont.Face7x13
+	img, _ := ebiten.NewImage(10, 20, ebiten.FilterNearest)
+	l := NewLayoutFromSpans([]Span{
+		{Text: "ab", Face: f},
+		{Image: img},
+		{Text: "c\nd", Color: color.White},
+	}, nil)
+	if len(l.Lines) != 2 {
+		t.Fatalf("len(Lines): got: %d, want: 2", len(l.Lines))
+	}
+	line := l.Lines[0]
+	if got, want := line.Width, fixed.I(31); got != want {
+		t.Errorf("Width: got: %v, want: %v", got, want)
+	}
+	if g := line.Glyphs[2]; g.Image != img || g.X != fixed.I(14) {
+		t.Errorf("the inline image: got: %v", g)
+	}
+	if g := line.Glyphs[3]; g.Face != f || g.Color != color.White || g.X != fixed.I(24) {
+		t.Errorf("the glyph after the inline image: got: %v", g)
+	}
+	// The second line has only the face's height.
+	if got, want := l.Lines[1].Y, fixed.I(13); got != want {
+		t.Errorf("Y: got: %v, want: %v", got, want)
+	}
+	if got, want := l.Bounds().Min.Y, -20; got != want {
+		t.Errorf("Bounds().Min.Y: got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+)
+
+// sdfSpread is the maximum distance in pixels of the base face that a signed distance field represents.
+const sdfSpread = 8
+
+type sdfPass int
+
+const (
+	sdfPassGlow sdfPass = iota
+	sdfPassOutline
+	sdfPassFill
+)
+
+// SDFFaceOptions represents options for NewSDFFace.
+type SDFFaceOptions struct {
+	// Scale is the scale of the face relative to the base face.
+	// If Scale is 0, 1 is used.
+	Scale float64
+
+	// OutlineWidth is the width of the outline in pixels after scaling.
+	// If OutlineWidth is 0, no outline is drawn.
+	OutlineWidth float64
+
+	// OutlineColor is the color of the outline.
+	OutlineColor color.Color
+
+	// GlowWidth is the width of the glow in pixels after scaling.
+	// If GlowWidth is 0, no glow is drawn.
+	GlowWidth float64
+
+	// GlowColor is the color of the glow.
+	GlowColor color.Color
+}
+
+type sdfFace struct {
+	base    font.Face
+	options SDFFaceOptions
+}
+
+// NewSDFFace returns a face that renders the glyphs of the base face with signed distance fields.
+//
+// The glyphs are cached as signed distance fields generated from the base face's glyphs,
+// and are thresholded when they are drawn.
+// The cached glyphs are shared among the SDF faces with the same base face regardless of the options,
+// so that one cache entry serves any sizes, outlines and glows.
+// The glyphs stay sharp when they are scaled up,
+// but the details smaller than a pixel of the base face are lost.
+// A big base face like 64 pixels is recommended.
+//
+// The outline and the glow can be as wide as 8 pixels of the base face, i.e., 8 * Scale pixels.
+// The alpha values of the colors are ignored: the glyphs, the outlines and the glows are drawn opaque.
+//
+// The metrics, the advances and the kerning of the face are the base face's ones scaled by the scale.
+// Glyph of the face returns the base face's glyph only when the scale is 1.
+//
+// If options is nil, the default options are used.
+func NewSDFFace(base font.Face, options *SDFFaceOptions) font.Face {
+	f := &sdfFace{
+		base: base,
+	}
+	if options != nil {
+		f.options = *options
+	}
+	if f.options.Scale == 0 {
+		f.options.Scale = 1
+	}
+	return f
+}
+
+func (f *sdfFace) scale(x fixed.Int26_6) fixed.Int26_6 {
+	return fixed.Int26_6(math.Round(float64(x) * f.options.Scale))
+}
+
+func (f *sdfFace) Close() error {
+	return f.base.Close()
+}
+
+func (f *sdfFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	if f.options.Scale != 1 {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	return f.base.Glyph(dot, r)
+}
+
+func (f *sdfFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	b, a, ok := f.base.GlyphBounds(r)
+	b = fixed.Rectangle26_6{
+		Min: fixed.Point26_6{X: f.scale(b.Min.X), Y: f.scale(b.Min.Y)},
+		Max: fixed.Point26_6{X: f.scale(b.Max.X), Y: f.scale(b.Max.Y)},
+	}
+	return b, f.scale(a), ok
+}
+
+func (f *sdfFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	a, ok := f.base.GlyphAdvance(r)
+	return f.scale(a), ok
+}
+
+func (f *sdfFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	return f.scale(f.base.Kern(r0, r1))
+}
+
+func (f *sdfFace) Metrics() font.Metrics {
+	m := f.base.Metrics()
+	m.Height = f.scale(m.Height)
+	m.Ascent = f.scale(m.Ascent)
+	m.Descent = f.scale(m.Descent)
+	m.XHeight = f.scale(m.XHeight)
+	m.CapHeight = f.scale(m.CapHeight)
+	return m
+}
+
+// HasGlyph implements glyphCoverer so that the base face's coverage is used in a fallback face.
+func (f *sdfFace) HasGlyph(r rune) bool {
+	return hasGlyph(f.base, r)
+}
+
+func (f *sdfFace) hasEffects() bool {
+	return (f.options.OutlineWidth > 0 && f.options.OutlineColor != nil) ||
+		(f.options.GlowWidth > 0 && f.options.GlowColor != nil)
+}
+
+// thresholdColorM returns the color matrix that maps the distance values in [lo, hi] to the alpha values in [0, 1]
+// with the color clr.
+func thresholdColorM(lo, hi float64, clr color.Color) ebiten.ColorM {
+	cr, cg, cb, ca := clr.RGBA()
+	var rf, gf, bf float64
+	if ca != 0 {
+		rf = float64(cr) / float64(ca)
+		gf = float64(cg) / float64(ca)
+		bf = float64(cb) / float64(ca)
+	}
+	k := 1 / math.Max(hi-lo, 1.0/256)
+	cm := ebiten.ColorM{}
+	cm.Scale(rf, gf, bf, k)
+	cm.Translate(0, 0, 0, -lo*k)
+	return cm
+}
+
+// drawGlyph draws the glyph of the rune at the dot position (x, y) in the pass.
+// If op is not nil, op is composed after the glyph's placement and color.
+//
+// drawGlyph must be called with textM locked.
+func (f *sdfFace) drawGlyph(dst *ebiten.Image, r rune, x, y fixed.Int26_6, clr color.Color, op *ebiten.DrawImageOptions, pass sdfPass, now int64) {
+	if f.options.Scale <= 0 {
+		return
+	}
+
+	// The distance of one pixel after scaling in the distance field's values.
+	px := 1 / (2 * sdfSpread * f.options.Scale)
+
+	var lo, hi float64
+	switch pass {
+	case sdfPassGlow:
+		if f.options.GlowWidth <= 0 || f.options.GlowColor == nil {
+			return
+		}
+		lo, hi = math.Max(0.5-f.options.GlowWidth*px, 0), 0.5
+		clr = f.options.GlowColor
+	case sdfPassOutline:
+		if f.options.OutlineWidth <= 0 || f.options.OutlineColor == nil {
+			return
+		}
+		w := f.options.OutlineWidth * px
+		lo, hi = 0.5-w-px/2, 0.5-w+px/2
+		clr = f.options.OutlineColor
+	case sdfPassFill:
+		if _, _, _, a := clr.RGBA(); a == 0 {
+			return
+		}
+		lo, hi = 0.5-px/2, 0.5+px/2
+	}
+
+	base := resolveFace(f.base, r)
+	g := getGlyphFromCache(fontFaceToFace(base), r, true, 0, now)
+	if g == nil || g.char.empty() {
+		return
+	}
+	g.draw(dst, x, y, f.options.Scale, thresholdColorM(lo, hi, clr), op)
+}
+
+// generateSDF converts the glyph image in the region (0, 0)-(w, h) of img into a signed distance field.
+//
+// The coverage of the glyph is taken from the alpha values, and the result is stored as white
+// with the distance values as alpha values: 0.5 is the edge,
+// 1 is inside by sdfSpread pixels or more, and 0 is outside by sdfSpread pixels or more.
+func generateSDF(img *image.RGBA, w, h int) {
+	b := img.Bounds()
+	if w > b.Dx() {
+		w = b.Dx()
+	}
+	if h > b.Dy() {
+		h = b.Dy()
+	}
+	inside := make([]bool, w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			inside[j*w+i] = img.Pix[img.PixOffset(b.Min.X+i, b.Min.Y+j)+3] >= 0x80
+		}
+	}
+
+	const maxDist2 = (sdfSpread + 1) * (sdfSpread + 1)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			in := inside[j*w+i]
+			d2 := maxDist2
+			for dj := -sdfSpread; dj <= sdfSpread; dj++ {
+				jj := j + dj
+				if jj < 0 || jj >= h {
+					if in {
+						// Outside of the image is outside of the glyph.
+						if dd := dj * dj; dd < d2 {
+							d2 = dd
+						}
+					}
+					continue
+				}
+				for di := -sdfSpread; di <= sdfSpread; di++ {
+					dd := di*di + dj*dj
+					if dd >= d2 {
+						continue
+					}
+					ii := i + di
+					if ii < 0 || ii >= w {
+						if in {
+							d2 = dd
+						}
+						continue
+					}
+					if inside[jj*w+ii] != in {
+						d2 = dd
+					}
+				}
+			}
+			// The edge is at the middle of the two pixels.
+			d := math.Sqrt(float64(d2)) - 0.5
+			if !in {
+				d = -d
+			}
+			v := 0.5 + d/(2*sdfSpread)
+			if v < 0 {
+				v = 0
+			}
+			if v > 1 {
+				v = 1
+			}
+			a := uint8(v * 0xff)
+			p := img.PixOffset(b.Min.X+i, b.Min.Y+j)
+			img.Pix[p] = a
+			img.Pix[p+1] = a
+			img.Pix[p+2] = a
+			img.Pix[p+3] = a
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+	. "github.com/dave/ebiten/text"
+)
+
+func TestSDFFace(t *testing.T) {
+	f := NewSDFFace(basicfont.Face7x13, &SDFFaceOptions{
+		Scale:        2,
+		OutlineWidth: 1,
+		OutlineColor: color.Black,
+	})
+
+	if got, want := f.Metrics().Height, fixed.I(26); got != want {
+		t.Errorf("Metrics().Height: got: %v, want: %v", got, want)
+	}
+	if got, want := Advance(f, "ab"), fixed.I(28); got != want {
+		t.Errorf("Advance: got: %v, want: %v", got, want)
+	}
+	if got, want := BoundString(f, "a"), image.Rect(0, -22, 12, 4); got != want {
+		t.Errorf("BoundString: got: %v, want: %v", got, want)
+	}
+}
+
+func TestSDFFaceCache(t *testing.T) {
+	f := NewSDFFace(newSquareFace(10, 26), &SDFFaceOptions{
+		Scale: 2,
+	})
+	dst, _ := ebiten.NewImage(64, 64, ebiten.FilterNearest)
+
+	DisposeFace(f)
+	ResetGlyphCacheStats()
+	Draw(dst, "ab\nc", f, 0, 32, color.White)
+	if got, want := ReadGlyphCacheStats().Misses, 3; got != want {
+		t.Errorf("Misses after the first Draw: got: %d, want: %d", got, want)
+	}
+
+	// The signed distance fields are rendered once, and are reused for any scales and colors.
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(4, 4)
+	op.ColorM.Scale(1, 0, 0, 1)
+	DrawWithOptions(dst, "ab\nc", f, op)
+	s := ReadGlyphCacheStats()
+	if got, want := s.Misses, 3; got != want {
+		t.Errorf("Misses after DrawWithOptions: got: %d, want: %d", got, want)
+	}
+	if got, want := s.Hits, 3; got != want {
+		t.Errorf("Hits after DrawWithOptions: got: %d, want: %d", got, want)
+	}
+}
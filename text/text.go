@@ -27,7 +27,6 @@ import (
 	"golang.org/x/image/math/fixed"
 
 	"github.com/dave/ebiten"
-	emath "github.com/dave/ebiten/internal/math"
 	"github.com/dave/ebiten/internal/sync"
 )
 
@@ -44,17 +43,25 @@ var (
 	faces = map[font.Face]struct{}{}
 )
 
-func fontFaceToFace(f font.Face) font.Face {
+// lookupFace returns the registered face that is the same as f.
+func lookupFace(f font.Face) (font.Face, bool) {
 	if _, ok := faces[f]; ok {
-		return f
+		return f, true
 	}
 	// If the (DeepEqual-ly) same font exists,
 	// reuse this to avoid to consume a lot of cache (#498).
 	for key := range faces {
 		if reflect.DeepEqual(key, f) {
-			return key
+			return key, true
 		}
 	}
+	return nil, false
+}
+
+func fontFaceToFace(f font.Face) font.Face {
+	if key, ok := lookupFace(f); ok {
+		return key
+	}
 	faces[f] = struct{}{}
 	return f
 }
@@ -65,12 +72,36 @@ var (
 )
 
 type char struct {
-	face   font.Face
-	rune   rune
-	atlasG int
+	face font.Face
+	rune rune
+
+	// sdf represents whether the glyph is cached as a signed distance field.
+	sdf bool
+
+	// outline is the radius in pixels by which the glyph is dilated to draw an outline.
+	outline int
 }
 
+// bounds returns the bounds of the glyph image in the cache.
+// For a signed distance field, the bounds are padded by the spread.
+// For a dilated glyph, the bounds are padded by the outline width.
 func (c *char) bounds() *fixed.Rectangle26_6 {
+	b := c.glyphBounds()
+	if !c.sdf && c.outline == 0 {
+		return b
+	}
+	p := fixed.I(c.outline)
+	if c.sdf {
+		p += fixed.I(sdfSpread)
+	}
+	return &fixed.Rectangle26_6{
+		Min: fixed.Point26_6{X: b.Min.X - p, Y: b.Min.Y - p},
+		Max: fixed.Point26_6{X: b.Max.X + p, Y: b.Max.Y + p},
+	}
+}
+
+// glyphBounds returns the bounds of the glyph reported by the face.
+func (c *char) glyphBounds() *fixed.Rectangle26_6 {
 	if m, ok := charBounds[c.face]; ok {
 		if b, ok := m[c.rune]; ok {
 			return b
@@ -89,35 +120,8 @@ func (c *char) size() (fixed.Int26_6, fixed.Int26_6) {
 }
 
 func (c *char) empty() bool {
-	x, y := c.size()
-	return x == 0 || y == 0
-}
-
-func (c *char) atlasGroup() int {
-	if c.atlasG != 0 {
-		return c.atlasG
-	}
-
-	x, y := c.size()
-	w, h := x.Ceil(), y.Ceil()
-	t := w
-	if t < h {
-		t = h
-	}
-
-	// Different images for small runes are inefficient.
-	// Let's use a same texture atlas for typical character sizes.
-	if t < 32 {
-		return 32
-	}
-	c.atlasG = emath.NextPowerOf2Int(t)
-	return c.atlasG
-}
-
-type glyph struct {
-	char  char
-	index int
-	atime int64
+	b := c.glyphBounds()
+	return b.Max.X == b.Min.X || b.Max.Y == b.Min.Y
 }
 
 func fixed26_6ToFloat64(x fixed.Int26_6) float64 {
@@ -133,16 +137,14 @@ var (
 	colorMCache = map[color.Color]*colorMCacheEntry{}
 )
 
-func (g *glyph) draw(dst *ebiten.Image, x, y fixed.Int26_6, clr color.Color) {
+// colorM returns the color matrix to render white glyphs in the color clr.
+// colorM returns false when clr is fully transparent.
+func colorM(clr color.Color) (ebiten.ColorM, bool) {
 	cr, cg, cb, ca := clr.RGBA()
 	if ca == 0 {
-		return
+		return ebiten.ColorM{}, false
 	}
 
-	b := g.char.bounds()
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(fixed26_6ToFloat64(x+b.Min.X), fixed26_6ToFloat64(y+b.Min.Y))
-
 	e, ok := colorMCache[clr]
 	if ok {
 		e.atime = now()
@@ -152,7 +154,7 @@ func (g *glyph) draw(dst *ebiten.Image, x, y fixed.Int26_6, clr color.Color) {
 			t := int64(math.MaxInt64)
 			for key, e := range colorMCache {
 				if e.atime < t {
-					t = g.atime
+					t = e.atime
 					oldest = key
 				}
 			}
@@ -170,160 +172,187 @@ func (g *glyph) draw(dst *ebiten.Image, x, y fixed.Int26_6, clr color.Color) {
 		}
 		colorMCache[clr] = e
 	}
-	op.ColorM = e.m
-
-	a := atlases[g.char.face][g.char.atlasGroup()]
-	sx, sy := a.at(g)
-	r := image.Rect(sx, sy, sx+a.glyphSize, sy+a.glyphSize)
-	op.SourceRect = &r
-
-	dst.DrawImage(a.image, op)
+	return e.m, true
 }
 
-var (
-	atlases = map[font.Face]map[int]*atlas{}
-)
-
-type atlas struct {
-	// image is the back-end image to hold glyph cache.
-	image *ebiten.Image
-
-	// tmpImage is the temporary image as a renderer source for glyph.
-	tmpImage *ebiten.Image
-
-	// glyphSize is the size of one glyph in the cache.
-	// This value is always power of 2.
-	glyphSize int
+var textM sync.Mutex
 
-	runeToGlyph map[rune]*glyph
+// GlyphImager is implemented by a face that has pre-rendered glyph images, like faces of the bitmapfont package.
+//
+// The glyphs of a GlyphImager are drawn directly from the images instead of being rendered into the glyph cache.
+// The glyph images should be white so that they are drawn in the specified colors.
+type GlyphImager interface {
+	font.Face
+
+	// GlyphImage returns the image that has the glyph of r, the rectangle of the glyph in the image,
+	// and the position of the rectangle's upper-left corner relative to the dot.
+	// GlyphImage returns false when the face doesn't have the glyph or the glyph is empty.
+	GlyphImage(r rune) (img *ebiten.Image, src image.Rectangle, offset image.Point, ok bool)
 }
 
-func (a *atlas) at(glyph *glyph) (int, int) {
-	if a.glyphSize != glyph.char.atlasGroup() {
-		panic("not reached")
+// concatDrawImageOptions composes the caller's options op after the glyph's options o.
+// op can be nil.
+func concatDrawImageOptions(o, op *ebiten.DrawImageOptions) {
+	if op == nil {
+		return
 	}
-	w, _ := a.image.Size()
-	xnum := w / a.glyphSize
-	x, y := glyph.index%xnum, glyph.index/xnum
-	return x * a.glyphSize, y * a.glyphSize
+	o.GeoM.Concat(op.GeoM)
+	o.ColorM.Concat(op.ColorM)
+	o.CompositeMode = op.CompositeMode
 }
 
-func (a *atlas) maxGlyphNum() int {
-	w, h := a.image.Size()
-	xnum := w / a.glyphSize
-	ynum := h / a.glyphSize
-	return xnum * ynum
+// drawnGlyph represents a glyph to draw at the dot position (x, y).
+type drawnGlyph struct {
+	face font.Face
+	rune rune
+	x    fixed.Int26_6
+	y    fixed.Int26_6
+	clr  color.Color
 }
 
-func (a *atlas) appendGlyph(char char, now int64) *glyph {
-	g := &glyph{
-		char:  char,
-		atime: now,
+// drawGlyphs draws the glyphs with the effects.
+// If op is not nil, op is composed after each glyph's placement and color.
+// effects can be nil.
+//
+// drawGlyphs must be called with textM locked.
+func drawGlyphs(dst *ebiten.Image, glyphs []drawnGlyph, op *ebiten.DrawImageOptions, effects *EffectOptions) {
+	n := now()
+
+	var outline int
+	var outlineColor color.Color
+	if effects != nil && effects.OutlineWidth > 0 && effects.OutlineColor != nil {
+		outline = effects.OutlineWidth
+		outlineColor = effects.OutlineColor
 	}
-	if len(a.runeToGlyph) == a.maxGlyphNum() {
-		var oldest *glyph
-		t := int64(math.MaxInt64)
-		for _, g := range a.runeToGlyph {
-			if g.atime < t {
-				t = g.atime
-				oldest = g
-			}
-		}
-		if oldest == nil {
-			panic("not reached")
+
+	// The shadows are drawn first so that they don't cover any glyphs.
+	// A shadow has the same shape as the outlined glyph.
+	if effects != nil && effects.ShadowOffset != (image.Point{}) && effects.ShadowColor != nil {
+		dx, dy := fixed.I(effects.ShadowOffset.X), fixed.I(effects.ShadowOffset.Y)
+		for _, g := range glyphs {
+			drawGlyph(dst, g.face, g.rune, g.x+dx, g.y+dy, effects.ShadowColor, outline, op, n)
 		}
-		idx := oldest.index
-		delete(a.runeToGlyph, oldest.char.rune)
+	}
 
-		g.index = idx
-	} else {
-		g.index = len(a.runeToGlyph)
+	// The glows and the outlines of signed distance fields are drawn before the glyphs
+	// so that they don't cover the other glyphs.
+	sdfEffects := false
+	for _, g := range glyphs {
+		if f, ok := resolveFace(g.face, g.rune).(*sdfFace); ok && f.hasEffects() {
+			sdfEffects = true
+			break
+		}
+	}
+	if sdfEffects {
+		for _, pass := range []sdfPass{sdfPassGlow, sdfPassOutline} {
+			for _, g := range glyphs {
+				if f, ok := resolveFace(g.face, g.rune).(*sdfFace); ok {
+					f.drawGlyph(dst, g.rune, g.x, g.y, g.clr, op, pass, n)
+				}
+			}
+		}
 	}
-	a.runeToGlyph[g.char.rune] = g
-	a.draw(g)
-	return g
-}
 
-func (a *atlas) draw(glyph *glyph) {
-	if a.tmpImage == nil {
-		a.tmpImage, _ = ebiten.NewImage(a.glyphSize, a.glyphSize, ebiten.FilterNearest)
+	if outlineColor != nil {
+		for _, g := range glyphs {
+			if !canDilate(resolveFace(g.face, g.rune)) {
+				continue
+			}
+			drawGlyph(dst, g.face, g.rune, g.x, g.y, outlineColor, outline, op, n)
+		}
 	}
 
-	dst := image.NewRGBA(image.Rect(0, 0, a.glyphSize, a.glyphSize))
-	d := font.Drawer{
-		Dst:  dst,
-		Src:  image.White,
-		Face: glyph.char.face,
+	for _, g := range glyphs {
+		drawGlyph(dst, g.face, g.rune, g.x, g.y, g.clr, 0, op, n)
 	}
-	b := glyph.char.bounds()
-	d.Dot = fixed.Point26_6{-b.Min.X, -b.Min.Y}
-	d.DrawString(string(glyph.char.rune))
-	a.tmpImage.ReplacePixels(dst.Pix)
-
-	op := &ebiten.DrawImageOptions{}
-	x, y := a.at(glyph)
-	op.GeoM.Translate(float64(x), float64(y))
-	op.CompositeMode = ebiten.CompositeModeCopy
-	a.image.DrawImage(a.tmpImage, op)
-
-	a.tmpImage.Clear()
 }
 
-func getGlyphFromCache(face font.Face, r rune, now int64) *glyph {
-	ch := char{
-		face: face,
-		rune: r,
+// drawGlyph draws the glyph of the rune at the dot position (x, y).
+// The glyph is dilated by outline pixels if the face can be dilated.
+// If op is not nil, op is composed after the glyph's placement and color.
+//
+// drawGlyph must be called with textM locked.
+func drawGlyph(dst *ebiten.Image, face font.Face, r rune, x, y fixed.Int26_6, clr color.Color, outline int, op *ebiten.DrawImageOptions, now int64) {
+	// The glyph cache is keyed on the face that actually has the glyph.
+	if f := resolveFace(face, r); f != face {
+		face = fontFaceToFace(f)
+	}
+	if f, ok := face.(*sdfFace); ok {
+		f.drawGlyph(dst, r, x, y, clr, op, sdfPassFill, now)
+		return
 	}
-	var at *atlas
-	if m, ok := atlases[face]; ok {
-		a, ok := m[ch.atlasGroup()]
-		if ok {
-			g, ok := a.runeToGlyph[r]
-			if ok {
-				g.atime = now
-				return g
+	if gi, ok := face.(GlyphImager); ok {
+		img, src, offset, ok := gi.GlyphImage(r)
+		if !ok {
+			return
+		}
+		cm, ok := colorM(clr)
+		if !ok {
+			return
+		}
+		o := &ebiten.DrawImageOptions{}
+		o.GeoM.Translate(fixed26_6ToFloat64(x)+float64(offset.X), fixed26_6ToFloat64(y)+float64(offset.Y))
+		o.ColorM = cm
+		o.SourceRect = &src
+		concatDrawImageOptions(o, op)
+		dst.DrawImage(img, o)
+		return
+	}
+	if g := getGlyphFromCache(face, r, false, outline, now); g != nil {
+		if !g.char.empty() {
+			cm, ok := colorM(clr)
+			if !ok {
+				return
 			}
+			g.draw(dst, x, y, 1, cm, op)
 		}
-		at = a
-	} else {
-		atlases[face] = map[int]*atlas{}
 	}
+}
 
-	if ch.empty() {
-		// The glyph doesn't have its size but might have valid 'advance' parameter
-		// when ch is e.g. space (U+0020).
-		return &glyph{
-			char:  ch,
-			atime: now,
-		}
+// lineHeight returns the distance between two baselines of the face.
+func lineHeight(face font.Face) fixed.Int26_6 {
+	m := face.Metrics()
+	if m.Height != 0 {
+		return m.Height
 	}
+	return m.Ascent + m.Descent
+}
 
-	if at == nil {
-		// Don't use ebiten.MaxImageSize here.
-		// It's because the back-end image pixels will be restored from GPU
-		// whenever a new glyph is rendered on the image, and restoring cost is
-		// expensive if the image is big.
-		// The back-end image is updated a temporary image, and the temporary image is
-		// always cleared after used. This means that there is no clue to restore
-		// the back-end image without reading from GPU
-		// (see the package 'restorable' implementation).
-		//
-		// TODO: How about making a new function for 'flagile' image?
-		const size = 1024
-		i, _ := ebiten.NewImage(size, size, ebiten.FilterNearest)
-		at = &atlas{
-			image:       i,
-			glyphSize:   ch.atlasGroup(),
-			runeToGlyph: map[rune]*glyph{},
+// layout calls f with each rune of the text and the dot position of the rune,
+// where the dot position of the first rune is (0, 0).
+// A newline ('\n') moves the dot to the start of the next line.
+// f might be nil.
+//
+// layout returns the advance of the longest line.
+//
+// layout must be called with textM locked.
+func layout(face font.Face, text string, f func(r rune, dot fixed.Point26_6)) fixed.Int26_6 {
+	var dot fixed.Point26_6
+	var advance fixed.Int26_6
+	prevC := rune(-1)
+	for _, c := range text {
+		if c == '\n' {
+			dot.X = 0
+			dot.Y += lineHeight(face)
+			prevC = -1
+			continue
+		}
+		if prevC >= 0 {
+			dot.X += face.Kern(prevC, c)
+		}
+		if f != nil {
+			f(c, dot)
 		}
-		atlases[face][ch.atlasGroup()] = at
+		a, _ := face.GlyphAdvance(c)
+		dot.X += a
+		if advance < dot.X {
+			advance = dot.X
+		}
+		prevC = c
 	}
-
-	return at.appendGlyph(ch, now)
+	return advance
 }
 
-var textM sync.Mutex
-
 // Draw draws a given text on a given destination image dst.
 //
 // face is the font for text rendering.
@@ -331,32 +360,97 @@ var textM sync.Mutex
 // Be careful that this doesn't represent left-upper corner position.
 // clr is the color for text rendering.
 //
+// A newline ('\n') in the text starts a new line.
+// The distance between the lines is the height of the face's metrics.
+//
 // Glyphs used for rendering are cached in least-recently-used way.
 // It is OK to call this function with a same text and a same face at every frame in terms of performance.
 //
 // This function is concurrent-safe.
 func Draw(dst *ebiten.Image, text string, face font.Face, x, y int, clr color.Color) {
 	textM.Lock()
+	drawText(dst, text, face, fixed.I(x), fixed.I(y), clr, nil, nil)
+	textM.Unlock()
+}
 
-	n := now()
-	fx := fixed.I(x)
-	prevC := rune(-1)
-
-	runes := []rune(text)
-	for _, c := range runes {
-		if prevC >= 0 {
-			fx += face.Kern(prevC, c)
-		}
-		fa := fontFaceToFace(face)
-		if g := getGlyphFromCache(fa, c, n); g != nil {
-			if !g.char.empty() {
-				g.draw(dst, fx, fixed.I(y), clr)
-			}
-			a, _ := face.GlyphAdvance(c)
-			fx += a
-		}
-		prevC = c
+// DrawWithOptions draws a given text on a given destination image dst with the options.
+//
+// face is the font for text rendering.
+// The text is placed so that the 'dot' (period) position of the first glyph is at the origin (0, 0),
+// and then the options' GeoM is applied.
+// The glyphs are white and the options' ColorM is applied to them.
+// For example, ColorM.Scale can specify the color of the text.
+// The options' CompositeMode is used to render the glyphs.
+// The other options like SourceRect are ignored.
+//
+// options can be nil. In this case, the text is drawn in white with the dot position at (0, 0).
+//
+// As well as Draw, glyphs are cached, and consecutive glyphs in the same cache are drawn efficiently at once.
+//
+// This function is concurrent-safe.
+func DrawWithOptions(dst *ebiten.Image, text string, face font.Face, options *ebiten.DrawImageOptions) {
+	if options == nil {
+		options = &ebiten.DrawImageOptions{}
 	}
 
+	textM.Lock()
+	drawText(dst, text, face, 0, 0, color.White, options, nil)
+	textM.Unlock()
+}
+
+// drawText draws the text with the dot position at (x, y) with the effects.
+// If op is not nil, op is composed after each glyph's placement and color.
+// effects can be nil.
+//
+// drawText must be called with textM locked.
+func drawText(dst *ebiten.Image, text string, face font.Face, x, y fixed.Int26_6, clr color.Color, op *ebiten.DrawImageOptions, effects *EffectOptions) {
+	fa := fontFaceToFace(face)
+	var glyphs []drawnGlyph
+	layout(face, text, func(c rune, dot fixed.Point26_6) {
+		glyphs = append(glyphs, drawnGlyph{
+			face: fa,
+			rune: c,
+			x:    x + dot.X,
+			y:    y + dot.Y,
+			clr:  clr,
+		})
+	})
+	drawGlyphs(dst, glyphs, op, effects)
+}
+
+// BoundString returns the bounding box of the text drawn by Draw with the dot position at (0, 0).
+//
+// The bounding box is the union of the glyphs' bounds reported by the face.
+// The bounding box might be smaller than the advance, e.g., the glyphs might not cover the spacing between the glyphs.
+// The minimum Y of the bounding box is usually negative, since the glyphs are above the dot.
+// For example, the text drawn by Draw at (x, y) is in BoundString(face, text).Add(image.Pt(x, y)).
+//
+// BoundString returns an empty rectangle when the text is empty.
+//
+// This function is concurrent-safe.
+func BoundString(face font.Face, text string) image.Rectangle {
+	textM.Lock()
+
+	var bounds fixed.Rectangle26_6
+	layout(face, text, func(c rune, dot fixed.Point26_6) {
+		b, _, _ := face.GlyphBounds(c)
+		bounds = bounds.Union(b.Add(dot))
+	})
+
+	textM.Unlock()
+
+	return image.Rect(bounds.Min.X.Floor(), bounds.Min.Y.Floor(), bounds.Max.X.Ceil(), bounds.Max.Y.Ceil())
+}
+
+// Advance returns the advance width of the text drawn by Draw, i.e., how far the dot moves.
+//
+// For a text with newlines ('\n'), Advance returns the advance width of the longest line.
+// Use Ceil or Round of the returned value to get the width in pixels.
+//
+// This function is concurrent-safe.
+func Advance(face font.Face, text string) fixed.Int26_6 {
+	textM.Lock()
+	a := layout(face, text, nil)
 	textM.Unlock()
+	return a
 }
@@ -0,0 +1,109 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+)
+
+func TestGlyphDrawImageOptions(t *testing.T) {
+	face := basicfont.Face7x13
+	g := &glyph{
+		char: char{
+			face: face,
+			rune: 'a',
+		},
+		x: 5,
+		y: 6,
+	}
+	// The top-left corner of the glyph is at (0, -11) from the dot.
+	b, _, _ := face.GlyphBounds('a')
+	if got, want := b.Min, fixed.P(0, -11); got != want {
+		t.Fatalf("GlyphBounds('a'): got: %v, want: %v", got, want)
+	}
+
+	var cm ebiten.ColorM
+	cm.Scale(1, 0, 0, 1)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(2, 3)
+	op.GeoM.Translate(10, 20)
+	op.ColorM.Scale(1, 1, 1, 0.5)
+	op.CompositeMode = ebiten.CompositeModeLighter
+
+	o := g.drawImageOptions(fixed.I(30), fixed.I(40), 1, cm, op)
+
+	// The glyph is placed at the dot position first, and then the caller's GeoM is applied.
+	if x, y := o.GeoM.Apply(0, 0); x != 2*30+10 || y != 3*(40-11)+20 {
+		t.Errorf("GeoM.Apply(0, 0): got: (%v, %v), want: (%v, %v)", x, y, 2*30+10, 3*(40-11)+20)
+	}
+	if got, want := *o.SourceRect, image.Rect(5, 6, 11, 19); got != want {
+		t.Errorf("SourceRect: got: %v, want: %v", got, want)
+	}
+	if got, want := color.RGBAModel.Convert(o.ColorM.Apply(color.White)), (color.RGBA{0x7f, 0, 0, 0x7f}); got != want {
+		t.Errorf("ColorM.Apply(color.White): got: %v, want: %v", got, want)
+	}
+	if got, want := o.CompositeMode, ebiten.CompositeModeLighter; got != want {
+		t.Errorf("CompositeMode: got: %v, want: %v", got, want)
+	}
+
+	// Without the caller's options, only the glyph's placement is applied.
+	o = g.drawImageOptions(fixed.I(30), fixed.I(40), 1, cm, nil)
+	if x, y := o.GeoM.Apply(0, 0); x != 30 || y != 40-11 {
+		t.Errorf("GeoM.Apply(0, 0) without options: got: (%v, %v), want: (%v, %v)", x, y, 30, 40-11)
+	}
+}
+
+func TestGenerateSDF(t *testing.T) {
+	// A square of 16x16 pixels at (8, 8).
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for j := 8; j < 24; j++ {
+		for i := 8; i < 24; i++ {
+			img.Pix[img.PixOffset(i, j)+3] = 0xff
+		}
+	}
+	generateSDF(img, 32, 32)
+
+	alpha := func(x, y int) uint8 {
+		return img.Pix[img.PixOffset(x, y)+3]
+	}
+	// The edge is between (7, 16) and (8, 16).
+	if a := alpha(7, 16); a >= 0x80 {
+		t.Errorf("alpha at (7, 16): got: %d, want: < 0x80", a)
+	}
+	if a := alpha(8, 16); a < 0x80 {
+		t.Errorf("alpha at (8, 16): got: %d, want: >= 0x80", a)
+	}
+	// The values increase toward the inside.
+	for x := 1; x <= 16; x++ {
+		if alpha(x-1, 16) > alpha(x, 16) {
+			t.Errorf("alpha at (%d, 16) is greater than at (%d, 16): %d > %d", x-1, x, alpha(x-1, 16), alpha(x, 16))
+		}
+	}
+	// The pixels farther than sdfSpread from the edge are saturated.
+	if a := alpha(0, 0); a != 0 {
+		t.Errorf("alpha at (0, 0): got: %d, want: 0", a)
+	}
+	if a := alpha(16, 16); a < 0xf0 {
+		t.Errorf("alpha at (16, 16): got: %d, want: >= 0xf0", a)
+	}
+}
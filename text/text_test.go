@@ -0,0 +1,77 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+	. "github.com/dave/ebiten/text"
+)
+
+func TestBoundString(t *testing.T) {
+	f := basicfont.Face7x13
+	cases := []struct {
+		Text string
+		Want image.Rectangle
+	}{
+		{"", image.Rectangle{}},
+		{"ab", image.Rect(0, -11, 13, 2)},
+		{"ab\nc", image.Rect(0, -11, 13, 15)},
+		{"a\n\n  c", image.Rect(0, -11, 20, 28)},
+	}
+	for _, c := range cases {
+		if got := BoundString(f, c.Text); got != c.Want {
+			t.Errorf("BoundString(%q): got: %v, want: %v", c.Text, got, c.Want)
+		}
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	f := basicfont.Face7x13
+	cases := []struct {
+		Text string
+		Want fixed.Int26_6
+	}{
+		{"", 0},
+		{"abc ", fixed.I(28)},
+		{"a\nbcd\nef", fixed.I(21)},
+	}
+	for _, c := range cases {
+		if got := Advance(f, c.Text); got != c.Want {
+			t.Errorf("Advance(%q): got: %v, want: %v", c.Text, got, c.Want)
+		}
+	}
+}
+
+func TestDrawWithOptions(t *testing.T) {
+	dst, _ := ebiten.NewImage(64, 64, ebiten.FilterNearest)
+	sdf := NewSDFFace(basicfont.Face7x13, &SDFFaceOptions{Scale: 2})
+	for _, face := range []font.Face{basicfont.Face7x13, sdf} {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Rotate(math.Pi / 4)
+		op.GeoM.Translate(32, 32)
+		op.ColorM.Scale(1, 0, 0, 1)
+		op.CompositeMode = ebiten.CompositeModeLighter
+		DrawWithOptions(dst, "ab\nc", face, op)
+		DrawWithOptions(dst, "ab\nc", face, nil)
+	}
+}
@@ -0,0 +1,783 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package textedit provides an editable text buffer for text input fields.
+//
+// Note: This package is experimental and API might be changed.
+package textedit
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/inpututil"
+	"github.com/dave/ebiten/text"
+)
+
+// Movement represents a movement of the caret.
+type Movement int
+
+// Movements
+const (
+	// MoveLeft moves the caret to the previous rune.
+	MoveLeft Movement = iota
+
+	// MoveRight moves the caret to the next rune.
+	MoveRight
+
+	// MoveWordLeft moves the caret to the start of the word before the caret.
+	MoveWordLeft
+
+	// MoveWordRight moves the caret to the end of the word after the caret.
+	MoveWordRight
+
+	// MoveUp moves the caret to the previous line keeping the horizontal position.
+	MoveUp
+
+	// MoveDown moves the caret to the next line keeping the horizontal position.
+	MoveDown
+
+	// MoveLineStart moves the caret to the start of the line.
+	MoveLineStart
+
+	// MoveLineEnd moves the caret to the end of the line.
+	MoveLineEnd
+
+	// MoveTextStart moves the caret to the start of the text.
+	MoveTextStart
+
+	// MoveTextEnd moves the caret to the end of the text.
+	MoveTextEnd
+)
+
+const (
+	// repeatDelay is the duration in ticks before a held key starts repeating.
+	repeatDelay = ebiten.FPS / 2
+
+	// repeatInterval is the interval in ticks of a repeating key.
+	repeatInterval = ebiten.FPS / 20
+
+	// blinkInterval is the interval in ticks of the caret's blinking.
+	blinkInterval = ebiten.FPS / 2
+)
+
+// Field is an editable text buffer with a caret and a selection for a text input field.
+//
+// The positions in the text like the caret are counted in runes.
+// The selection is the range between the caret and the anchor: when the selection is empty,
+// the anchor is at the same position as the caret.
+//
+// The zero value of Field is an empty single-line field.
+// Field is not concurrent-safe.
+type Field struct {
+	// Face is the font face to render the text.
+	// Face is also used to move the caret vertically and to locate the caret with the mouse cursor.
+	Face font.Face
+
+	// Multiline represents whether the text can have newlines.
+	// If Multiline is false, newlines in an inserted text are replaced with spaces.
+	Multiline bool
+
+	// MaxLength is the maximum number of runes in the text.
+	// If MaxLength is 0, the length is not limited.
+	MaxLength int
+
+	// Width is the width of the field in pixels used to determine whether a mouse press is on the field.
+	// If Width is 0, the width of the text is used.
+	Width int
+
+	// SelectionColor is the background color of the selected text.
+	// If SelectionColor is nil, translucent blue is used.
+	SelectionColor color.Color
+
+	// ReadClipboard is called to read the clipboard on pasting.
+	// If ReadClipboard is nil, ebiten.ClipboardText is used.
+	ReadClipboard func() string
+
+	// WriteClipboard is called to write the clipboard on copying and cutting.
+	// If WriteClipboard is nil, ebiten.SetClipboardText is used.
+	WriteClipboard func(text string)
+
+	runes  []rune
+	caret  int
+	anchor int
+
+	// goalX is the horizontal position that the vertical movements of the caret keep.
+	// goalX is valid when hasGoalX is true.
+	goalX    fixed.Int26_6
+	hasGoalX bool
+
+	// blink is the ticks since the caret moved last.
+	blink int
+
+	// dragging represents whether the selection is being made by dragging the mouse.
+	dragging bool
+
+	// drawX and drawY are the position where the field was drawn last.
+	drawX int
+	drawY int
+	drawn bool
+
+	// imeX and imeY are the position of the IME candidate window set last.
+	imeX   int
+	imeY   int
+	imeSet bool
+}
+
+// Text returns the text.
+func (f *Field) Text() string {
+	return string(f.runes)
+}
+
+// SetText sets the text, and moves the caret to the end of the text.
+//
+// The text is truncated to MaxLength runes, and newlines are replaced with spaces unless Multiline is true.
+func (f *Field) SetText(text string) {
+	f.runes = nil
+	f.caret = 0
+	f.anchor = 0
+	f.Insert(text)
+}
+
+// Len returns the number of runes in the text.
+func (f *Field) Len() int {
+	return len(f.runes)
+}
+
+// Caret returns the position of the caret.
+func (f *Field) Caret() int {
+	return f.caret
+}
+
+// SetCaret moves the caret to the position, and clears the selection.
+// The position is clamped to the text.
+func (f *Field) SetCaret(pos int) {
+	f.moveCaretTo(pos, false)
+}
+
+// Selection returns the range of the selection. start is always less than or equal to end.
+func (f *Field) Selection() (start, end int) {
+	if f.anchor < f.caret {
+		return f.anchor, f.caret
+	}
+	return f.caret, f.anchor
+}
+
+// Select selects the range from start to end. The caret is moved to end.
+// The positions are clamped to the text.
+func (f *Field) Select(start, end int) {
+	f.anchor = f.clamp(start)
+	f.moveCaretTo(end, true)
+}
+
+// SelectAll selects the whole text.
+func (f *Field) SelectAll() {
+	f.Select(0, len(f.runes))
+}
+
+// SelectedText returns the selected text.
+func (f *Field) SelectedText() string {
+	s, e := f.Selection()
+	return string(f.runes[s:e])
+}
+
+func (f *Field) clamp(pos int) int {
+	if pos < 0 {
+		return 0
+	}
+	if pos > len(f.runes) {
+		return len(f.runes)
+	}
+	return pos
+}
+
+// moveCaretTo moves the caret to pos.
+// If selecting is true, the anchor stays and the selection is extended.
+func (f *Field) moveCaretTo(pos int, selecting bool) {
+	f.caret = f.clamp(pos)
+	if !selecting {
+		f.anchor = f.caret
+	}
+	f.hasGoalX = false
+	f.blink = 0
+}
+
+// Insert replaces the selection with the text, and moves the caret to the end of the inserted text.
+//
+// The text is truncated so that the length doesn't exceed MaxLength,
+// and newlines are replaced with spaces unless Multiline is true.
+func (f *Field) Insert(text string) {
+	if !f.Multiline {
+		text = strings.Replace(text, "\r\n", " ", -1)
+		text = strings.Replace(text, "\n", " ", -1)
+	}
+	rs := []rune(text)
+
+	s, e := f.Selection()
+	if f.MaxLength > 0 {
+		if n := f.MaxLength - (len(f.runes) - (e - s)); len(rs) > n {
+			if n < 0 {
+				n = 0
+			}
+			rs = rs[:n]
+		}
+	}
+
+	runes := make([]rune, 0, len(f.runes)-(e-s)+len(rs))
+	runes = append(runes, f.runes[:s]...)
+	runes = append(runes, rs...)
+	runes = append(runes, f.runes[e:]...)
+	f.runes = runes
+	f.moveCaretTo(s+len(rs), false)
+}
+
+// deleteRange deletes the selection if it is not empty, or the range between the caret and pos otherwise.
+func (f *Field) deleteRange(pos int) {
+	s, e := f.Selection()
+	if s == e {
+		s, e = f.caret, f.clamp(pos)
+		if e < s {
+			s, e = e, s
+		}
+	}
+	f.runes = append(f.runes[:s], f.runes[e:]...)
+	f.moveCaretTo(s, false)
+}
+
+// DeleteBackward deletes the selection, or the rune before the caret if the selection is empty.
+func (f *Field) DeleteBackward() {
+	f.deleteRange(f.caret - 1)
+}
+
+// DeleteForward deletes the selection, or the rune after the caret if the selection is empty.
+func (f *Field) DeleteForward() {
+	f.deleteRange(f.caret + 1)
+}
+
+// DeleteWordBackward deletes the selection, or the word before the caret if the selection is empty.
+func (f *Field) DeleteWordBackward() {
+	f.deleteRange(f.wordStart(f.caret))
+}
+
+// DeleteWordForward deletes the selection, or the word after the caret if the selection is empty.
+func (f *Field) DeleteWordForward() {
+	f.deleteRange(f.wordEnd(f.caret))
+}
+
+// Copy writes the selected text to the clipboard.
+// Copy does nothing if the selection is empty.
+func (f *Field) Copy() {
+	s, e := f.Selection()
+	if s == e {
+		return
+	}
+	f.writeClipboard(string(f.runes[s:e]))
+}
+
+// Cut writes the selected text to the clipboard and deletes the selection.
+// Cut does nothing if the selection is empty.
+func (f *Field) Cut() {
+	s, e := f.Selection()
+	if s == e {
+		return
+	}
+	f.writeClipboard(string(f.runes[s:e]))
+	f.deleteRange(f.caret)
+}
+
+// Paste replaces the selection with the text in the clipboard.
+func (f *Field) Paste() {
+	if t := f.readClipboard(); t != "" {
+		f.Insert(t)
+	}
+}
+
+func (f *Field) readClipboard() string {
+	if f.ReadClipboard != nil {
+		return f.ReadClipboard()
+	}
+	return ebiten.ClipboardText()
+}
+
+func (f *Field) writeClipboard(text string) {
+	if f.WriteClipboard != nil {
+		f.WriteClipboard(text)
+		return
+	}
+	ebiten.SetClipboardText(text)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordStart returns the start of the word before pos.
+func (f *Field) wordStart(pos int) int {
+	for pos > 0 && !isWordRune(f.runes[pos-1]) {
+		pos--
+	}
+	for pos > 0 && isWordRune(f.runes[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordEnd returns the end of the word after pos.
+func (f *Field) wordEnd(pos int) int {
+	for pos < len(f.runes) && !isWordRune(f.runes[pos]) {
+		pos++
+	}
+	for pos < len(f.runes) && isWordRune(f.runes[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// lineStart returns the start of the line at pos.
+func (f *Field) lineStart(pos int) int {
+	for pos > 0 && f.runes[pos-1] != '\n' {
+		pos--
+	}
+	return pos
+}
+
+// lineEnd returns the end of the line at pos, excluding the newline.
+func (f *Field) lineEnd(pos int) int {
+	for pos < len(f.runes) && f.runes[pos] != '\n' {
+		pos++
+	}
+	return pos
+}
+
+// MoveCaret moves the caret by the movement.
+//
+// If selecting is true, the selection is extended to the new caret position.
+// Otherwise, the selection is cleared. MoveLeft and MoveRight without selecting move the caret
+// to the edge of the selection if the selection is not empty.
+func (f *Field) MoveCaret(m Movement, selecting bool) {
+	s, e := f.Selection()
+	pos := f.caret
+	switch m {
+	case MoveLeft:
+		if !selecting && s != e {
+			pos = s
+		} else {
+			pos--
+		}
+	case MoveRight:
+		if !selecting && s != e {
+			pos = e
+		} else {
+			pos++
+		}
+	case MoveWordLeft:
+		pos = f.wordStart(pos)
+	case MoveWordRight:
+		pos = f.wordEnd(pos)
+	case MoveUp, MoveDown:
+		f.moveVertically(m == MoveDown, selecting)
+		return
+	case MoveLineStart:
+		pos = f.lineStart(pos)
+	case MoveLineEnd:
+		pos = f.lineEnd(pos)
+	case MoveTextStart:
+		pos = 0
+	case MoveTextEnd:
+		pos = len(f.runes)
+	}
+	f.moveCaretTo(pos, selecting)
+}
+
+func (f *Field) moveVertically(down bool, selecting bool) {
+	start := f.lineStart(f.caret)
+	goalX := f.goalX
+	if !f.hasGoalX {
+		goalX = f.advance(start, f.caret)
+	}
+
+	var pos int
+	if down {
+		end := f.lineEnd(f.caret)
+		if end == len(f.runes) {
+			pos = end
+		} else {
+			pos = f.indexAtX(end+1, goalX)
+		}
+	} else {
+		if start == 0 {
+			pos = 0
+		} else {
+			pos = f.indexAtX(f.lineStart(start-1), goalX)
+		}
+	}
+	f.moveCaretTo(pos, selecting)
+
+	// Keep the horizontal position for the next vertical movements.
+	f.goalX = goalX
+	f.hasGoalX = true
+}
+
+// advance returns the width of the runes from start to end, which must be in the same line.
+func (f *Field) advance(start, end int) fixed.Int26_6 {
+	if f.Face == nil {
+		return fixed.I(end - start)
+	}
+	return text.Advance(f.Face, string(f.runes[start:end]))
+}
+
+// indexAtX returns the position nearest to the horizontal position x in the line starting at start.
+func (f *Field) indexAtX(start int, x fixed.Int26_6) int {
+	end := f.lineEnd(start)
+	pos := start
+	var prev fixed.Int26_6
+	for i := start + 1; i <= end; i++ {
+		a := f.advance(start, i)
+		if x < a {
+			// Choose the nearer edge of the rune.
+			if x-prev < a-x {
+				return i - 1
+			}
+			return i
+		}
+		prev = a
+		pos = i
+	}
+	return pos
+}
+
+func lineHeight(face font.Face) fixed.Int26_6 {
+	m := face.Metrics()
+	if m.Height != 0 {
+		return m.Height
+	}
+	return m.Ascent + m.Descent
+}
+
+// IndexAt returns the position in the text nearest to the point (x, y).
+// (x, y) is relative to the dot position where the field is drawn by Draw.
+//
+// IndexAt returns 0 if Face is nil.
+func (f *Field) IndexAt(x, y int) int {
+	if f.Face == nil {
+		return 0
+	}
+	line := int((fixed.I(y) + f.Face.Metrics().Ascent) / lineHeight(f.Face))
+	if fixed.I(y)+f.Face.Metrics().Ascent < 0 {
+		line = 0
+	}
+	start := 0
+	for ; line > 0; line-- {
+		end := f.lineEnd(start)
+		if end == len(f.runes) {
+			break
+		}
+		start = end + 1
+	}
+	return f.indexAtX(start, fixed.I(x))
+}
+
+// bounds returns the bounds of the field relative to the dot position.
+func (f *Field) bounds() image.Rectangle {
+	if f.Face == nil {
+		return image.Rectangle{}
+	}
+	m := f.Face.Metrics()
+	h := lineHeight(f.Face)
+	n := 1
+	var w fixed.Int26_6
+	for start := 0; ; {
+		end := f.lineEnd(start)
+		if a := f.advance(start, end); w < a {
+			w = a
+		}
+		if end == len(f.runes) {
+			break
+		}
+		start = end + 1
+		n++
+	}
+	width := w.Ceil()
+	if width < f.Width {
+		width = f.Width
+	}
+	// Include the caret at the end of the lines.
+	width++
+	top := -m.Ascent
+	return image.Rect(0, top.Floor(), width, (top + h*fixed.Int26_6(n)).Ceil())
+}
+
+func isKeyRepeated(key ebiten.Key) bool {
+	d := inpututil.KeyPressDuration(key)
+	if d == 1 {
+		return true
+	}
+	return d >= repeatDelay && (d-repeatDelay)%repeatInterval == 0
+}
+
+type keyMovement struct {
+	key  ebiten.Key
+	move Movement
+
+	// ctrlMove is the movement when the control key is pressed.
+	ctrlMove Movement
+}
+
+var keyMovements = []keyMovement{
+	{ebiten.KeyLeft, MoveLeft, MoveWordLeft},
+	{ebiten.KeyRight, MoveRight, MoveWordRight},
+	{ebiten.KeyUp, MoveUp, MoveUp},
+	{ebiten.KeyDown, MoveDown, MoveDown},
+	{ebiten.KeyHome, MoveLineStart, MoveTextStart},
+	{ebiten.KeyEnd, MoveLineEnd, MoveTextEnd},
+}
+
+// Update updates the field by the user's input in this frame.
+//
+// The characters from ebiten.InputChars are inserted, and Enter inserts a newline if Multiline is true.
+// Backspace and Delete delete the selection or a rune, or a word with Control.
+// Left, Right, Up, Down, Home and End move the caret, and extend the selection with Shift.
+// With Control, Left and Right move the caret by a word,
+// and Home and End move the caret to the start and the end of the text.
+// Control+A selects the whole text. Control+C, Control+X and Control+V copy, cut and paste the text.
+// Command (Super) works as well as Control for these shortcuts, e.g., Command+C copies the text on macOS.
+// The other key bindings of macOS like Option+Left and Command+Left are not supported.
+// The keys are repeated while they are held down.
+//
+// While the IME is composing a text, the keys are left to the IME and only the committed text is inserted.
+// The composition is rendered by Draw when ebiten.IMEComposition reports it, i.e., on browsers and Windows.
+// On browsers, the IME works only while it is enabled by ebiten.SetIMEEnabled:
+// enable the IME while the field has focus.
+//
+// Pressing the left mouse button on the field moves the caret, and dragging the mouse selects the text.
+// Pressing with Shift extends the selection. The field's position is where the field was drawn last by Draw.
+//
+// Update should be called every frame in the game's update function while the field has focus.
+func (f *Field) Update() {
+	f.blink++
+
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl)
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	// Command is used for the shortcuts instead of Control on macOS.
+	super := ebiten.IsKeyPressed(ebiten.KeyLeftSuper) || ebiten.IsKeyPressed(ebiten.KeyRightSuper)
+	// Control with Alt is AltGr on some keyboards, which inputs characters.
+	shortcut := (ctrl || super) && !ebiten.IsKeyPressed(ebiten.KeyAlt)
+
+	if shortcut {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyA):
+			f.SelectAll()
+		case inpututil.IsKeyJustPressed(ebiten.KeyC):
+			f.Copy()
+		case inpututil.IsKeyJustPressed(ebiten.KeyX):
+			f.Cut()
+		case isKeyRepeated(ebiten.KeyV):
+			f.Paste()
+		}
+	} else {
+		var chars []rune
+		for _, r := range ebiten.InputChars() {
+			if unicode.IsControl(r) {
+				continue
+			}
+			chars = append(chars, r)
+		}
+		if len(chars) > 0 {
+			f.Insert(string(chars))
+		}
+	}
+
+	if c, _ := ebiten.IMEComposition(); c == "" {
+		f.updateKeys(ctrl, shift)
+	}
+	f.updateMouse(shift)
+}
+
+func (f *Field) updateKeys(ctrl, shift bool) {
+	if f.Multiline && (isKeyRepeated(ebiten.KeyEnter) || isKeyRepeated(ebiten.KeyKPEnter)) {
+		f.Insert("\n")
+	}
+	if isKeyRepeated(ebiten.KeyBackspace) {
+		if ctrl {
+			f.DeleteWordBackward()
+		} else {
+			f.DeleteBackward()
+		}
+	}
+	if isKeyRepeated(ebiten.KeyDelete) {
+		if ctrl {
+			f.DeleteWordForward()
+		} else {
+			f.DeleteForward()
+		}
+	}
+	for _, k := range keyMovements {
+		if !isKeyRepeated(k.key) {
+			continue
+		}
+		if ctrl {
+			f.MoveCaret(k.ctrlMove, shift)
+		} else {
+			f.MoveCaret(k.move, shift)
+		}
+	}
+}
+
+func (f *Field) updateMouse(shift bool) {
+	if !f.drawn {
+		return
+	}
+	x, y := ebiten.CursorPosition()
+	x -= f.drawX
+	y -= f.drawY
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		f.dragging = false
+		if !image.Pt(x, y).In(f.bounds()) {
+			return
+		}
+		f.moveCaretTo(f.IndexAt(x, y), shift)
+		f.dragging = true
+		return
+	}
+	if !f.dragging {
+		return
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		f.dragging = false
+		return
+	}
+	f.moveCaretTo(f.IndexAt(x, y), true)
+}
+
+var (
+	whiteImage *ebiten.Image
+)
+
+func fillRect(dst *ebiten.Image, x0, y0, x1, y1 fixed.Int26_6, clr color.Color) {
+	if x1 <= x0 || y1 <= y0 {
+		return
+	}
+	if whiteImage == nil {
+		whiteImage, _ = ebiten.NewImage(16, 16, ebiten.FilterNearest)
+		whiteImage.Fill(color.White)
+	}
+	cr, cg, cb, ca := clr.RGBA()
+	if ca == 0 {
+		return
+	}
+
+	w, h := whiteImage.Size()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(x1-x0)/64/float64(w), float64(y1-y0)/64/float64(h))
+	op.GeoM.Translate(float64(x0)/64, float64(y0)/64)
+	op.ColorM.Scale(float64(cr)/float64(ca), float64(cg)/float64(ca), float64(cb)/float64(ca), float64(ca)/0xffff)
+	dst.DrawImage(whiteImage, op)
+}
+
+// Draw draws the field with the selection and the caret on dst.
+//
+// (x, y) represents the 'dot' (period) position of the first line like text.Draw.
+// clr is the color of the text and the caret. The caret blinks while the caret doesn't move.
+//
+// The text being composed by the IME is drawn underlined at the caret.
+// Draw also sets the IME candidate window position below the caret, assuming that dst is the screen.
+//
+// Draw does nothing if Face is nil.
+func (f *Field) Draw(dst *ebiten.Image, x, y int, clr color.Color) {
+	if f.Face == nil {
+		return
+	}
+	f.drawX = x
+	f.drawY = y
+	f.drawn = true
+
+	m := f.Face.Metrics()
+	h := lineHeight(f.Face)
+	ox, oy := fixed.I(x), fixed.I(y)-m.Ascent
+
+	selClr := f.SelectionColor
+	if selClr == nil {
+		selClr = color.RGBA{0, 0x40, 0x80, 0x80}
+	}
+	s, e := f.Selection()
+	if s != e {
+		ly := oy
+		for start := 0; ; {
+			end := f.lineEnd(start)
+			if s <= end && start <= e {
+				ss, ee := s, e
+				if ss < start {
+					ss = start
+				}
+				if ee > end {
+					ee = end
+				}
+				x0 := ox + f.advance(start, ss)
+				x1 := ox + f.advance(start, ee)
+				if e > end {
+					// Show that the newline is selected.
+					x1 += fixed.I(4)
+				}
+				fillRect(dst, x0, ly, x1, ly+h, selClr)
+			}
+			if end == len(f.runes) {
+				break
+			}
+			start = end + 1
+			ly += h
+		}
+	}
+
+	start := f.lineStart(f.caret)
+	line := 0
+	for i := 0; i < start; i++ {
+		if f.runes[i] == '\n' {
+			line++
+		}
+	}
+	cx := ox + f.advance(start, f.caret)
+	cy := oy + h*fixed.Int26_6(line)
+
+	comp, compCaret := ebiten.IMEComposition()
+	if comp == "" {
+		text.Draw(dst, string(f.runes), f.Face, x, y, clr)
+	} else {
+		// Draw the composition as if it were inserted at the caret.
+		t := string(f.runes[:f.caret]) + comp + string(f.runes[f.caret:])
+		text.Draw(dst, t, f.Face, x, y, clr)
+		w := text.Advance(f.Face, comp)
+		fillRect(dst, cx, cy+m.Ascent+fixed.I(1), cx+w, cy+m.Ascent+fixed.I(2), clr)
+		cr := []rune(comp)
+		if compCaret < 0 || compCaret > len(cr) {
+			compCaret = len(cr)
+		}
+		cx += text.Advance(f.Face, string(cr[:compCaret]))
+	}
+
+	if ix, iy := cx.Floor(), (cy + m.Ascent + m.Descent).Ceil(); !f.imeSet || f.imeX != ix || f.imeY != iy {
+		ebiten.SetIMECandidateWindowPosition(ix, iy)
+		f.imeX, f.imeY, f.imeSet = ix, iy, true
+	}
+
+	if comp != "" || (f.blink/blinkInterval)%2 == 0 {
+		fillRect(dst, cx, cy, cx+fixed.I(1), cy+m.Ascent+m.Descent, clr)
+	}
+}
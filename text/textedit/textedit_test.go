@@ -0,0 +1,177 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textedit_test
+
+import (
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+
+	"github.com/dave/ebiten"
+	"github.com/dave/ebiten/inputtest"
+	. "github.com/dave/ebiten/text/textedit"
+)
+
+func TestFieldEdit(t *testing.T) {
+	f := &Field{}
+	f.SetText("héllo wörld")
+	if got, want := f.Caret(), 11; got != want {
+		t.Errorf("Caret(): got: %d, want: %d", got, want)
+	}
+
+	f.MoveCaret(MoveWordLeft, false)
+	f.DeleteBackward()
+	if got, want := f.Text(), "héllowörld"; got != want {
+		t.Errorf("Text() after DeleteBackward: got: %q, want: %q", got, want)
+	}
+
+	f.MoveCaret(MoveLeft, false)
+	f.MoveCaret(MoveLeft, true)
+	f.MoveCaret(MoveLeft, true)
+	if got, want := f.SelectedText(), "ll"; got != want {
+		t.Errorf("SelectedText(): got: %q, want: %q", got, want)
+	}
+	f.Insert("\n")
+	if got, want := f.Text(), "hé owörld"; got != want {
+		t.Errorf("Text() after Insert: got: %q, want: %q", got, want)
+	}
+
+	f.MoveCaret(MoveLineEnd, false)
+	f.DeleteWordBackward()
+	if got, want := f.Text(), "hé "; got != want {
+		t.Errorf("Text() after DeleteWordBackward: got: %q, want: %q", got, want)
+	}
+
+	f.MaxLength = 5
+	f.Insert("abcdef")
+	if got, want := f.Text(), "hé ab"; got != want {
+		t.Errorf("Text() with MaxLength: got: %q, want: %q", got, want)
+	}
+}
+
+func TestFieldMultiline(t *testing.T) {
+	f := &Field{
+		Face:      basicfont.Face7x13,
+		Multiline: true,
+	}
+	f.SetText("abcd\nef\nghij")
+	f.SetCaret(3)
+	f.MoveCaret(MoveDown, true)
+	if got, want := f.Caret(), 7; got != want {
+		t.Errorf("Caret() after MoveDown: got: %d, want: %d", got, want)
+	}
+	// The horizontal position is kept through the shorter line.
+	f.MoveCaret(MoveDown, true)
+	if got, want := f.Caret(), 11; got != want {
+		t.Errorf("Caret() after MoveDown twice: got: %d, want: %d", got, want)
+	}
+	if got, want := f.SelectedText(), "d\nef\nghi"; got != want {
+		t.Errorf("SelectedText(): got: %q, want: %q", got, want)
+	}
+
+	if got, want := f.IndexAt(15, 13), 7; got != want {
+		t.Errorf("IndexAt(15, 13): got: %d, want: %d", got, want)
+	}
+
+	dst, _ := ebiten.NewImage(64, 64, ebiten.FilterNearest)
+	f.Draw(dst, 0, 16, color.White)
+}
+
+func TestFieldClipboard(t *testing.T) {
+	var clipboard string
+	f := &Field{
+		ReadClipboard:  func() string { return clipboard },
+		WriteClipboard: func(text string) { clipboard = text },
+	}
+	f.SetText("foo bar")
+	f.Select(0, 3)
+	f.Cut()
+	if got, want := clipboard, "foo"; got != want {
+		t.Errorf("clipboard after Cut: got: %q, want: %q", got, want)
+	}
+	f.MoveCaret(MoveTextEnd, false)
+	f.Paste()
+	if got, want := f.Text(), " barfoo"; got != want {
+		t.Errorf("Text() after Paste: got: %q, want: %q", got, want)
+	}
+}
+
+func TestFieldUpdate(t *testing.T) {
+	defer inputtest.Reset()
+
+	f := &Field{}
+	inputtest.AppendInputChars('a', 'あ', 'c')
+	if err := inputtest.Update(func() error {
+		f.Update()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Text(), "aあc"; got != want {
+		t.Errorf("Text(): got: %q, want: %q", got, want)
+	}
+
+	// Backspace is repeated after the delay.
+	inputtest.PressKey(ebiten.KeyBackspace)
+	for i := 0; i < ebiten.FPS/2-1; i++ {
+		if err := inputtest.Update(func() error {
+			f.Update()
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := f.Text(), "aあ"; got != want {
+		t.Errorf("Text() before repeating: got: %q, want: %q", got, want)
+	}
+	if err := inputtest.Update(func() error {
+		f.Update()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Text(), "a"; got != want {
+		t.Errorf("Text() after repeating: got: %q, want: %q", got, want)
+	}
+}
+
+func TestFieldCommandShortcut(t *testing.T) {
+	defer inputtest.Reset()
+
+	// Release the keys pressed by the other tests.
+	inputtest.Reset()
+	if err := inputtest.Update(func() error {
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Field{}
+	f.SetText("abc")
+
+	// Command+A selects the whole text as well as Control+A.
+	inputtest.PressKey(ebiten.KeyLeftSuper)
+	inputtest.PressKey(ebiten.KeyA)
+	if err := inputtest.Update(func() error {
+		f.Update()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if s, e := f.Selection(); s != 0 || e != 3 {
+		t.Errorf("Selection(): got: (%d, %d), want: (0, 3)", s, e)
+	}
+}